@@ -17,6 +17,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/version"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
 	distr "github.com/cosmos/cosmos-sdk/x/distribution"
 	"github.com/cosmos/cosmos-sdk/x/genaccounts"
 	"github.com/cosmos/cosmos-sdk/x/genutil"
@@ -48,15 +49,18 @@ var (
 		params.AppModuleBasic{},
 		slashing.AppModuleBasic{},
 		supply.AppModuleBasic{},
+		crisis.AppModuleBasic{},
 
 		nameservice.AppModule{},
 	)
 	// account permissions
 	maccPerms = map[string][]string{
-		auth.FeeCollectorName:     nil,
-		distr.ModuleName:          nil,
-		staking.BondedPoolName:    {supply.Burner, supply.Staking},
-		staking.NotBondedPoolName: {supply.Burner, supply.Staking},
+		auth.FeeCollectorName:          nil,
+		distr.ModuleName:               nil,
+		staking.BondedPoolName:         {supply.Burner, supply.Staking},
+		staking.NotBondedPoolName:      {supply.Burner, supply.Staking},
+		nameservice.EscrowAccountName:  nil,
+		nameservice.AuctionAccountName: {supply.Burner},
 	}
 )
 
@@ -73,6 +77,11 @@ type nameServiceApp struct {
 	*bam.BaseApp
 	cdc *codec.Codec
 
+	invCheckPeriod uint
+
+	// Set while the nameservice store is behind the module's StoreVersion
+	migrateStore bool
+
 	// keys to access the substores
 	keys  map[string]*sdk.KVStoreKey
 	tkeys map[string]*sdk.TransientStoreKey
@@ -84,6 +93,7 @@ type nameServiceApp struct {
 	slashingKeeper slashing.Keeper
 	distrKeeper    distr.Keeper
 	supplyKeeper   supply.Keeper
+	crisisKeeper   crisis.Keeper
 	paramsKeeper   params.Keeper
 	nsKeeper       nameservice.Keeper
 
@@ -91,9 +101,10 @@ type nameServiceApp struct {
 	mm *module.Manager
 }
 
-// NewNameServiceApp is a constructor function for nameServiceApp
+// NewNameServiceApp is a constructor function for nameServiceApp. The registered invariants
+// are asserted every invCheckPeriod blocks, or never if it is zero
 func NewNameServiceApp(
-	logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.BaseApp),
+	logger log.Logger, db dbm.DB, invCheckPeriod uint, baseAppOptions ...func(*bam.BaseApp),
 ) *nameServiceApp {
 
 	// First define the top level codec that will be shared by the different modules
@@ -111,10 +122,11 @@ func NewNameServiceApp(
 
 	// Here you initialize your application with the store keys it requires
 	var app = &nameServiceApp{
-		BaseApp: bApp,
-		cdc:     cdc,
-		keys:    keys,
-		tkeys:   tkeys,
+		BaseApp:        bApp,
+		cdc:            cdc,
+		invCheckPeriod: invCheckPeriod,
+		keys:           keys,
+		tkeys:          tkeys,
 	}
 
 	// The ParamsKeeper handles parameter storage for the application
@@ -125,6 +137,8 @@ func NewNameServiceApp(
 	stakingSubspace := app.paramsKeeper.Subspace(staking.DefaultParamspace)
 	distrSubspace := app.paramsKeeper.Subspace(distr.DefaultParamspace)
 	slashingSubspace := app.paramsKeeper.Subspace(slashing.DefaultParamspace)
+	crisisSubspace := app.paramsKeeper.Subspace(crisis.DefaultParamspace)
+	nameserviceSubspace := app.paramsKeeper.Subspace(nameservice.DefaultParamspace)
 
 	// The AccountKeeper handles address -> account lookups
 	app.accountKeeper = auth.NewAccountKeeper(
@@ -180,6 +194,14 @@ func NewNameServiceApp(
 		slashing.DefaultCodespace,
 	)
 
+	// The crisis keeper halts the chain when a registered invariant is broken
+	app.crisisKeeper = crisis.NewKeeper(
+		crisisSubspace,
+		invCheckPeriod,
+		app.supplyKeeper,
+		auth.FeeCollectorName,
+	)
+
 	// register the staking hooks
 	// NOTE: stakingKeeper above is passed by reference, so that it will contain these hooks
 	app.stakingKeeper = *stakingKeeper.SetHooks(
@@ -192,8 +214,10 @@ func NewNameServiceApp(
 	// It handles interactions with the namestore
 	app.nsKeeper = nameservice.NewKeeper(
 		app.bankKeeper,
+		app.supplyKeeper,
 		keys[nameservice.StoreKey],
 		app.cdc,
+		nameserviceSubspace,
 	)
 
 	app.mm = module.NewManager(
@@ -201,6 +225,7 @@ func NewNameServiceApp(
 		genutil.NewAppModule(app.accountKeeper, app.stakingKeeper, app.BaseApp.DeliverTx),
 		auth.NewAppModule(app.accountKeeper),
 		bank.NewAppModule(app.bankKeeper, app.accountKeeper),
+		crisis.NewAppModule(&app.crisisKeeper),
 		nameservice.NewAppModule(app.nsKeeper, app.bankKeeper),
 		supply.NewAppModule(app.supplyKeeper, app.accountKeeper),
 		distr.NewAppModule(app.distrKeeper, app.supplyKeeper),
@@ -209,7 +234,7 @@ func NewNameServiceApp(
 	)
 
 	app.mm.SetOrderBeginBlockers(distr.ModuleName, slashing.ModuleName)
-	app.mm.SetOrderEndBlockers(staking.ModuleName)
+	app.mm.SetOrderEndBlockers(crisis.ModuleName, staking.ModuleName, nameservice.ModuleName)
 
 	// Sets the order of Genesis - Order matters, genutil is to always come last
 	// NOTE: The genutils moodule must occur after staking so that pools are
@@ -223,10 +248,12 @@ func NewNameServiceApp(
 		slashing.ModuleName,
 		nameservice.ModuleName,
 		supply.ModuleName,
+		crisis.ModuleName,
 		genutil.ModuleName,
 	)
 	logger.Info("666NAMESERVICE STORENAME", nameservice.ModuleName, nameservice.StoreKey)
-	// register all module routes and module queriers
+	// register all module invariants, routes and module queriers
+	app.mm.RegisterInvariants(&app.crisisKeeper)
 	app.mm.RegisterRoutes(app.Router(), app.QueryRouter())
 
 	// The initChainer handles translating the genesis.json file into initial state for the network
@@ -252,6 +279,9 @@ func NewNameServiceApp(
 		cmn.Exit(err.Error())
 	}
 
+	// A store written by an older version of the module is migrated once, in the first block this binary runs
+	app.migrateStore = app.nsKeeper.GetStoreVersion(app.NewContext(true, abci.Header{})) < nameservice.StoreVersion
+
 	return app
 }
 
@@ -274,6 +304,10 @@ func (app *nameServiceApp) InitChainer(ctx sdk.Context, req abci.RequestInitChai
 }
 
 func (app *nameServiceApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	if app.migrateStore {
+		app.nsKeeper.MigrateStore(ctx)
+		app.migrateStore = false
+	}
 	return app.mm.BeginBlock(ctx, req)
 }
 func (app *nameServiceApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
@@ -301,6 +335,10 @@ func (app *nameServiceApp) ExportAppStateAndValidators(forZeroHeight bool, jailW
 	// as if they could withdraw from the start of the next block
 	ctx := app.NewContext(true, abci.Header{Height: app.LastBlockHeight()})
 
+	if forZeroHeight {
+		app.prepForZeroHeightGenesis(ctx)
+	}
+
 	genState := app.mm.ExportGenesis(ctx)
 	appState, err = codec.MarshalJSONIndent(app.cdc, genState)
 	if err != nil {
@@ -311,3 +349,12 @@ func (app *nameServiceApp) ExportAppStateAndValidators(forZeroHeight bool, jailW
 
 	return appState, validators, nil
 }
+
+// prepForZeroHeightGenesis moves height based state back so that it stays valid for a chain restarting at height zero
+func (app *nameServiceApp) prepForZeroHeightGenesis(ctx sdk.Context) {
+	// pending escrow closes keep the remainder of their dispute period, names the remainder of their lifetime
+	// and auctions the remainder of their commit and reveal phases
+	app.nsKeeper.RebaseClosingQueue(ctx, ctx.BlockHeight())
+	app.nsKeeper.RebaseExpiryQueue(ctx, ctx.BlockHeight())
+	app.nsKeeper.RebaseAuctionQueue(ctx, ctx.BlockHeight())
+}
@@ -21,6 +21,10 @@ import (
 	dbm "github.com/tendermint/tm-db"
 )
 
+const flagInvCheckPeriod = "inv-check-period"
+
+var invCheckPeriod uint
+
 func main() {
 	cobra.EnableCommandSorting = false
 
@@ -56,6 +60,8 @@ func main() {
 
 	// prepare and add flags
 	executor := cli.PrepareBaseCmd(rootCmd, "NS", app.DefaultNodeHome)
+	rootCmd.PersistentFlags().UintVar(&invCheckPeriod, flagInvCheckPeriod,
+		0, "Assert registered invariants every N blocks")
 	err := executor.Execute()
 	if err != nil {
 		panic(err)
@@ -63,7 +69,7 @@ func main() {
 }
 
 func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application {
-	return app.NewNameServiceApp(logger, db)
+	return app.NewNameServiceApp(logger, db, invCheckPeriod)
 }
 
 func exportAppStateAndTMValidators(
@@ -71,7 +77,7 @@ func exportAppStateAndTMValidators(
 ) (json.RawMessage, []tmtypes.GenesisValidator, error) {
 
 	if height != -1 {
-		nsApp := app.NewNameServiceApp(logger, db)
+		nsApp := app.NewNameServiceApp(logger, db, uint(1))
 		err := nsApp.LoadHeight(height)
 		if err != nil {
 			return nil, nil, err
@@ -79,7 +85,7 @@ func exportAppStateAndTMValidators(
 		return nsApp.ExportAppStateAndValidators(forZeroHeight, jailWhiteList)
 	}
 
-	nsApp := app.NewNameServiceApp(logger, db)
+	nsApp := app.NewNameServiceApp(logger, db, uint(1))
 
 	return nsApp.ExportAppStateAndValidators(forZeroHeight, jailWhiteList)
 }
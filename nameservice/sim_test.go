@@ -0,0 +1,288 @@
+package app
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/simapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+	distrsim "github.com/cosmos/cosmos-sdk/x/distribution/simulation"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+	slashingsim "github.com/cosmos/cosmos-sdk/x/slashing/simulation"
+	"github.com/cosmos/cosmos-sdk/x/staking"
+	stakingsim "github.com/cosmos/cosmos-sdk/x/staking/simulation"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+
+	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice"
+	nssim "github.com/cosmos/sdk-tutorials/nameservice/x/nameservice/simulation"
+)
+
+// Run with:
+// go test . -run TestFullAppSimulation -Enabled=true -NumBlocks=100 -BlockSize=200 -Commit=true -Seed=42 -v -timeout 24h
+var (
+	paramsFile         string
+	seed               int64
+	numBlocks          int
+	blockSize          int
+	enabled            bool
+	verbose            bool
+	lean               bool
+	commit             bool
+	period             int
+	onOperation        bool
+	allInvariants      bool
+	genesisTime        int64
+	initialBlockHeight int
+)
+
+func init() {
+	flag.StringVar(&paramsFile, "Params", "", "custom simulation params file which overrides any random params")
+	flag.Int64Var(&seed, "Seed", 42, "simulation random seed")
+	flag.IntVar(&initialBlockHeight, "InitialBlockHeight", 1, "initial block to start the simulation")
+	flag.IntVar(&numBlocks, "NumBlocks", 500, "number of new blocks to simulate from the initial block height")
+	flag.IntVar(&blockSize, "BlockSize", 200, "operations per block")
+	flag.BoolVar(&enabled, "Enabled", false, "enable the simulation")
+	flag.BoolVar(&verbose, "Verbose", false, "verbose log output")
+	flag.BoolVar(&lean, "Lean", false, "lean simulation log output")
+	flag.BoolVar(&commit, "Commit", false, "have the simulation commit")
+	flag.IntVar(&period, "Period", 1, "run slow invariants only once every period assertions")
+	flag.BoolVar(&onOperation, "SimulateEveryOperation", false, "run slow invariants every operation")
+	flag.BoolVar(&allInvariants, "PrintAllInvariants", false, "print all invariants if a broken invariant is found")
+	flag.Int64Var(&genesisTime, "GenesisTime", 0, "override genesis UNIX time instead of using a random UNIX time")
+}
+
+func appParams() simulation.AppParams {
+	ap := make(simulation.AppParams)
+	if paramsFile != "" {
+		bz, err := ioutil.ReadFile(paramsFile)
+		if err != nil {
+			panic(err)
+		}
+		MakeCodec().MustUnmarshalJSON(bz, &ap)
+	}
+	return ap
+}
+
+func appStateFn(
+	r *rand.Rand, accs []simulation.Account,
+) (appState json.RawMessage, simAccs []simulation.Account, chainID string, genesisTimestamp time.Time) {
+
+	cdc := MakeCodec()
+	ap := appParams()
+
+	if genesisTime == 0 {
+		genesisTimestamp = simulation.RandTimestamp(r)
+	} else {
+		genesisTimestamp = time.Unix(genesisTime, 0)
+	}
+
+	genesisState := NewDefaultGenesisState()
+
+	var (
+		amount             int64
+		numInitiallyBonded int64
+	)
+	ap.GetOrGenerate(cdc, simapp.StakePerAccount, &amount, r,
+		func(r *rand.Rand) { amount = int64(r.Intn(1e12)) })
+	ap.GetOrGenerate(cdc, simapp.InitiallyBondedValidators, &numInitiallyBonded, r,
+		func(r *rand.Rand) { numInitiallyBonded = int64(r.Intn(250)) })
+
+	numAccs := int64(len(accs))
+	if numInitiallyBonded > numAccs {
+		numInitiallyBonded = numAccs
+	}
+
+	simapp.GenGenesisAccounts(cdc, r, accs, genesisTimestamp, amount, numInitiallyBonded, genesisState)
+	simapp.GenAuthGenesisState(cdc, r, ap, genesisState)
+	simapp.GenBankGenesisState(cdc, r, ap, genesisState)
+	simapp.GenSupplyGenesisState(cdc, amount, numInitiallyBonded, numAccs, genesisState)
+	simapp.GenDistrGenesisState(cdc, r, ap, genesisState)
+	stakingGen := simapp.GenStakingGenesisState(cdc, r, accs, amount, numAccs, numInitiallyBonded, ap, genesisState)
+	simapp.GenSlashingGenesisState(cdc, r, stakingGen, ap, genesisState)
+	nssim.GenNameserviceGenesisState(cdc, r, accs, ap, genesisState)
+
+	appState, err := cdc.MarshalJSON(genesisState)
+	if err != nil {
+		panic(err)
+	}
+
+	return appState, accs, "simulation", genesisTimestamp
+}
+
+func testAndRunTxs(app *nameServiceApp) []simulation.WeightedOperation {
+	ap := appParams()
+
+	return append([]simulation.WeightedOperation{
+		{Weight: 100, Op: bank.SimulateMsgSend(app.accountKeeper, app.bankKeeper)},
+		{Weight: 50, Op: distrsim.SimulateMsgSetWithdrawAddress(app.accountKeeper, app.distrKeeper)},
+		{Weight: 50, Op: distrsim.SimulateMsgWithdrawDelegatorReward(app.accountKeeper, app.distrKeeper)},
+		{Weight: 50, Op: distrsim.SimulateMsgWithdrawValidatorCommission(app.accountKeeper, app.distrKeeper)},
+		{Weight: 100, Op: stakingsim.SimulateMsgCreateValidator(app.accountKeeper, app.stakingKeeper)},
+		{Weight: 5, Op: stakingsim.SimulateMsgEditValidator(app.stakingKeeper)},
+		{Weight: 100, Op: stakingsim.SimulateMsgDelegate(app.accountKeeper, app.stakingKeeper)},
+		{Weight: 100, Op: stakingsim.SimulateMsgUndelegate(app.accountKeeper, app.stakingKeeper)},
+		{Weight: 100, Op: stakingsim.SimulateMsgBeginRedelegate(app.accountKeeper, app.stakingKeeper)},
+		{Weight: 100, Op: slashingsim.SimulateMsgUnjail(app.slashingKeeper)},
+	}, nssim.WeightedOperations(ap, app.cdc, app.nsKeeper)...)
+}
+
+func invariants(app *nameServiceApp) []sdk.Invariant {
+	invs := []sdk.Invariant{
+		supply.AllInvariants(app.supplyKeeper),
+		distr.AllInvariants(app.distrKeeper),
+		staking.AllInvariants(app.stakingKeeper),
+		nameservice.AllInvariants(app.nsKeeper),
+	}
+	return simulation.PeriodicInvariants(invs, period, 0)
+}
+
+// getSimulationLog decodes the nameservice store pairs and leaves every other store to simapp
+func getSimulationLog(storeName string, cdcA, cdcB *codec.Codec, kvA, kvB cmn.KVPair) string {
+	if storeName == nameservice.StoreKey && (len(kvA.Value) != 0 || len(kvB.Value) != 0) {
+		return nssim.DecodeStore(cdcA, cdcB, kvA, kvB)
+	}
+	return simapp.GetSimulationLog(storeName, cdcA, cdcB, kvA, kvB)
+}
+
+func runSimulation(tb testing.TB, app *nameServiceApp, seed int64, invs []sdk.Invariant) (bool, error) {
+	stopEarly, _, err := simulation.SimulateFromSeed(
+		tb, os.Stdout, app.BaseApp, appStateFn, seed, testAndRunTxs(app), invs,
+		initialBlockHeight, numBlocks, 0, blockSize, "", false, commit, lean,
+		onOperation, allInvariants, app.ModuleAccountAddrs(),
+	)
+	return stopEarly, err
+}
+
+func newSimLogger() log.Logger {
+	if verbose {
+		return log.TestingLogger()
+	}
+	return log.NewNopLogger()
+}
+
+// Pass this in as an option to use a dbStoreAdapter instead of an IAVLStore for simulation speed.
+func fauxMerkleModeOpt(bapp *baseapp.BaseApp) {
+	bapp.SetFauxMerkleMode()
+}
+
+func TestFullAppSimulation(t *testing.T) {
+	if !enabled {
+		t.Skip("Skipping application simulation")
+	}
+
+	app := NewNameServiceApp(newSimLogger(), dbm.NewMemDB(), 0, fauxMerkleModeOpt)
+	require.Equal(t, appName, app.Name())
+
+	_, err := runSimulation(t, app, seed, invariants(app))
+	require.NoError(t, err)
+}
+
+func TestAppImportExport(t *testing.T) {
+	if !enabled {
+		t.Skip("Skipping application import/export simulation")
+	}
+
+	app := NewNameServiceApp(newSimLogger(), dbm.NewMemDB(), 0, fauxMerkleModeOpt)
+	require.Equal(t, appName, app.Name())
+
+	_, err := runSimulation(t, app, seed, invariants(app))
+	require.NoError(t, err)
+
+	fmt.Printf("Exporting genesis...\n")
+
+	appState, _, err := app.ExportAppStateAndValidators(false, []string{})
+	require.NoError(t, err)
+
+	fmt.Printf("Importing genesis...\n")
+
+	newApp := NewNameServiceApp(log.NewNopLogger(), dbm.NewMemDB(), 0, fauxMerkleModeOpt)
+	require.Equal(t, appName, newApp.Name())
+
+	var genesisState GenesisState
+	err = app.cdc.UnmarshalJSON(appState, &genesisState)
+	require.NoError(t, err)
+
+	ctxB := newApp.NewContext(true, abci.Header{Height: app.LastBlockHeight()})
+	newApp.mm.InitGenesis(ctxB, genesisState)
+
+	fmt.Printf("Comparing stores...\n")
+	ctxA := app.NewContext(true, abci.Header{Height: app.LastBlockHeight()})
+
+	storeKeysPrefixes := []struct {
+		A        sdk.StoreKey
+		B        sdk.StoreKey
+		Prefixes [][]byte
+	}{
+		{app.keys[baseapp.MainStoreKey], newApp.keys[baseapp.MainStoreKey], [][]byte{}},
+		{app.keys[auth.StoreKey], newApp.keys[auth.StoreKey], [][]byte{}},
+		{app.keys[staking.StoreKey], newApp.keys[staking.StoreKey],
+			[][]byte{
+				staking.UnbondingQueueKey, staking.RedelegationQueueKey, staking.ValidatorQueueKey,
+			}}, // ordering may change but it doesn't matter
+		{app.keys[slashing.StoreKey], newApp.keys[slashing.StoreKey], [][]byte{}},
+		{app.keys[distr.StoreKey], newApp.keys[distr.StoreKey], [][]byte{}},
+		{app.keys[supply.StoreKey], newApp.keys[supply.StoreKey], [][]byte{}},
+		{app.keys[params.StoreKey], newApp.keys[params.StoreKey], [][]byte{}},
+		{app.keys[nameservice.StoreKey], newApp.keys[nameservice.StoreKey], [][]byte{}},
+	}
+
+	for _, storeKeysPrefix := range storeKeysPrefixes {
+		storeA := ctxA.KVStore(storeKeysPrefix.A)
+		storeB := ctxB.KVStore(storeKeysPrefix.B)
+		kvA, kvB, count, equal := sdk.DiffKVStores(storeA, storeB, storeKeysPrefix.Prefixes)
+		fmt.Printf("Compared %d key/value pairs between %s and %s\n", count, storeKeysPrefix.A, storeKeysPrefix.B)
+		require.True(t, equal, getSimulationLog(storeKeysPrefix.A.Name(), app.cdc, newApp.cdc, kvA, kvB))
+	}
+}
+
+func TestAppStateDeterminism(t *testing.T) {
+	if !enabled {
+		t.Skip("Skipping application simulation")
+	}
+
+	numSeeds := 3
+	numTimesToRunPerSeed := 5
+	appHashList := make([]json.RawMessage, numTimesToRunPerSeed)
+
+	for i := 0; i < numSeeds; i++ {
+		seed := rand.Int63()
+
+		for j := 0; j < numTimesToRunPerSeed; j++ {
+			app := NewNameServiceApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+
+			fmt.Printf(
+				"Running non-determinism simulation; seed: %d/%d (%d), attempt: %d/%d\n",
+				i+1, numSeeds, seed, j+1, numTimesToRunPerSeed,
+			)
+
+			_, err := runSimulation(t, app, seed, []sdk.Invariant{})
+			require.NoError(t, err)
+
+			appHashList[j] = app.LastCommitID().Hash
+		}
+
+		for k := 1; k < numTimesToRunPerSeed; k++ {
+			require.Equal(t, appHashList[0], appHashList[k], "appHash list: %v", appHashList)
+		}
+	}
+}
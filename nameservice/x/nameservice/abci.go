@@ -0,0 +1,92 @@
+package nameservice
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice/internal/types"
+)
+
+// EndBlocker pays out every escrow whose dispute period has ended, releases every name whose grace period has ended
+// and settles every auction whose reveal phase has ended
+func EndBlocker(ctx sdk.Context, keeper Keeper) {
+	payoutMaturedEscrows(ctx, keeper)
+	releaseExpiredNames(ctx, keeper)
+	settleEndedAuctions(ctx, keeper)
+}
+
+func payoutMaturedEscrows(ctx sdk.Context, keeper Keeper) {
+	// Collect the matured escrows first, since paying out removes them from the queue
+	var matured []string
+	itr := keeper.ClosingQueueIterator(ctx, ctx.BlockHeight())
+	for ; itr.Valid(); itr.Next() {
+		matured = append(matured, string(itr.Value()))
+	}
+	itr.Close()
+
+	for _, channelID := range matured {
+		// Pay out in a cached context so that a failed payout leaves the escrow untouched to be retried
+		cacheCtx, writeCache := ctx.CacheContext()
+		escrow := keeper.GetEscrow(cacheCtx, channelID)
+		if err := keeper.PayoutEscrow(cacheCtx, channelID, escrow.Close.Balance); err != nil {
+			ctx.Logger().Error(fmt.Sprintf("failed to pay out escrow %s: %s", channelID, err))
+			continue
+		}
+		writeCache()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	}
+}
+
+// releaseExpiredNames deletes every name that expired more than the grace period ago, so that it can be
+// bought again at the min name price
+func releaseExpiredNames(ctx sdk.Context, keeper Keeper) {
+	releaseHeight := ctx.BlockHeight() - keeper.GetParams(ctx).NameGracePeriod
+	if releaseHeight < 0 {
+		return
+	}
+
+	// Collect the expired names first, since deleting them removes them from the queue
+	var expired []string
+	itr := keeper.ExpiryQueueIterator(ctx, releaseHeight)
+	for ; itr.Valid(); itr.Next() {
+		expired = append(expired, string(itr.Value()))
+	}
+	itr.Close()
+
+	for _, name := range expired {
+		whois := keeper.GetWhois(ctx, name)
+		keeper.DeleteWhois(ctx, name)
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeExpireName,
+				sdk.NewAttribute(types.AttributeKeyName, name),
+				sdk.NewAttribute(types.AttributeKeyOwner, whois.Owner.String()),
+				sdk.NewAttribute(types.AttributeKeyExpiryHeight, strconv.FormatInt(whois.ExpiryHeight, 10)),
+			),
+		)
+	}
+}
+
+func settleEndedAuctions(ctx sdk.Context, keeper Keeper) {
+	// Collect the ended auctions first, since settling removes them from the queue
+	var ended []string
+	itr := keeper.AuctionQueueIterator(ctx, ctx.BlockHeight())
+	for ; itr.Valid(); itr.Next() {
+		ended = append(ended, string(itr.Value()))
+	}
+	itr.Close()
+
+	for _, name := range ended {
+		// Settle in a cached context so that a failed settlement leaves the auction untouched to be retried
+		cacheCtx, writeCache := ctx.CacheContext()
+		if err := keeper.SettleAuction(cacheCtx, name); err != nil {
+			ctx.Logger().Error(fmt.Sprintf("failed to settle auction for %s: %s", name, err))
+			continue
+		}
+		writeCache()
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	}
+}
@@ -0,0 +1,166 @@
+package nameservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice/internal/types"
+)
+
+// createExpiryTestInput returns a keeper whose names live for 100 blocks with a grace period of 20,
+// and an owner who bought "name" and "other" at height one
+func createExpiryTestInput(t *testing.T) (sdk.Context, Keeper, sdk.AccAddress) {
+	ctx, k := createTestInput(t)
+	params := DefaultParams()
+	params.NameLifetime = 100
+	params.NameGracePeriod = 20
+	params.NameRenewalFee = sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+	k.SetParams(ctx, params)
+
+	owner := testAddr("owner")
+	fundAccount(t, ctx, k, owner, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10)))
+	handler := NewHandler(k)
+	for _, name := range []string{"name", "other"} {
+		res := handler(ctx, NewMsgBuyName(name, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1)), owner))
+		require.True(t, res.IsOK(), res.Log)
+	}
+	require.Equal(t, int64(101), k.GetWhois(ctx, "name").ExpiryHeight)
+	return ctx, k, owner
+}
+
+func TestNameExpiry(t *testing.T) {
+	ctx, k, owner := createExpiryTestInput(t)
+	handler := NewHandler(k)
+	buyer := testAddr("buyer")
+	fundAccount(t, ctx, k, buyer, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10)))
+
+	res := handler(ctx.WithBlockHeight(100), NewMsgSetName("name", "value", owner))
+	require.True(t, res.IsOK(), res.Log)
+
+	// An expired name can neither be set by its owner nor bought during the grace period
+	expired := ctx.WithBlockHeight(101)
+	res = handler(expired, NewMsgSetName("name", "other value", owner))
+	require.Equal(t, types.CodeNameExpired, res.Code)
+	res = handler(expired, NewMsgBuyName("name", sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5)), buyer))
+	require.Equal(t, types.CodeNameExpired, res.Code)
+	require.Equal(t, "value", k.ResolveName(ctx, "name"))
+
+	// The name is held for its owner until the grace period ends
+	EndBlocker(ctx.WithBlockHeight(120), k)
+	require.Equal(t, owner, k.GetOwner(ctx, "name"))
+	require.Equal(t, uint64(2), k.GetNameCount(ctx))
+}
+
+func TestNameRenewal(t *testing.T) {
+	ctx, k, owner := createExpiryTestInput(t)
+	handler := NewHandler(k)
+
+	// Renewing before expiry extends the name from its expiry
+	res := handler(ctx.WithBlockHeight(50), NewMsgRenewName("other", owner))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, int64(201), k.GetWhois(ctx, "other").ExpiryHeight)
+
+	// Renewing during the grace period extends the name from the renewal
+	res = handler(ctx.WithBlockHeight(110), NewMsgRenewName("name", owner))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, int64(210), k.GetWhois(ctx, "name").ExpiryHeight)
+
+	// Each renewal pays the renewal fee to the fee collector, which was also paid for both names
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 4)), k.SupplyKeeper.GetModuleAccount(ctx, auth.FeeCollectorName).GetCoins())
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 6)), k.CoinKeeper.GetCoins(ctx, owner))
+
+	EndBlocker(ctx.WithBlockHeight(121), k)
+	require.Equal(t, owner, k.GetOwner(ctx, "name"))
+	require.Equal(t, owner, k.GetOwner(ctx, "other"))
+
+	res = handler(ctx.WithBlockHeight(121), NewMsgSetName("name", "value", owner))
+	require.True(t, res.IsOK(), res.Log)
+
+	// Only the owner can renew a name
+	res = handler(ctx.WithBlockHeight(121), NewMsgRenewName("name", testAddr("buyer")))
+	require.False(t, res.IsOK())
+}
+
+func TestNameReleaseAfterGracePeriod(t *testing.T) {
+	ctx, k, owner := createExpiryTestInput(t)
+	handler := NewHandler(k)
+
+	// The names are released by the first block after the grace period
+	EndBlocker(ctx.WithBlockHeight(121), k)
+	require.False(t, k.IsNamePresent(ctx, "name"))
+	require.False(t, k.IsNamePresent(ctx, "other"))
+	require.Equal(t, uint64(0), k.GetNameCount(ctx))
+	iterator := k.ExpiryQueueIterator(ctx, 1000)
+	require.False(t, iterator.Valid())
+	iterator.Close()
+
+	// A released name can no longer be renewed, but can be bought again at the min name price
+	released := ctx.WithBlockHeight(122)
+	res := handler(released, NewMsgRenewName("name", owner))
+	require.Equal(t, types.CodeNameDoesNotExist, res.Code)
+
+	buyer := testAddr("buyer")
+	fundAccount(t, ctx, k, buyer, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10)))
+	res = handler(released, NewMsgBuyName("name", k.GetParams(ctx).MinNamePrice, buyer))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, buyer, k.GetOwner(ctx, "name"))
+	require.Equal(t, int64(222), k.GetWhois(ctx, "name").ExpiryHeight)
+}
+
+func TestPayoutMaturedClose(t *testing.T) {
+	ctx, k := createTestInput(t)
+	handler := NewHandler(k)
+	merchant, customer := testAddr("merchant"), testAddr("customer")
+	channelID, _, walletKey := createTestOrder(t, ctx, k, merchant, customer, 10)
+
+	balance := NewChannelBalance(channelID, 1, stake(15), stake(5))
+	res := handler(ctx, NewMsgClaimOrder(merchant, channelID, 1, stake(15), stake(5), signTestBalance(balance, walletKey)))
+	require.True(t, res.IsOK(), res.Log)
+
+	// The close is held until the end of its dispute period
+	EndBlocker(ctx.WithBlockHeight(100), k)
+	require.True(t, k.GetEscrow(ctx, channelID).IsClosing())
+	require.True(t, k.CoinKeeper.GetCoins(ctx, merchant).IsZero())
+
+	// The matured close is paid out exactly once
+	for _, height := range []int64{101, 101, 102} {
+		EndBlocker(ctx.WithBlockHeight(height), k)
+		require.False(t, k.IsEscrowPresent(ctx, channelID))
+		require.Equal(t, stake(15), k.CoinKeeper.GetCoins(ctx, merchant))
+		require.Equal(t, stake(5), k.CoinKeeper.GetCoins(ctx, customer))
+		require.True(t, k.SupplyKeeper.GetModuleAccount(ctx, EscrowAccountName).GetCoins().IsZero())
+	}
+}
+
+func TestPayoutMaturedCloseFailure(t *testing.T) {
+	ctx, k := createTestInput(t)
+	handler := NewHandler(k)
+	merchant, customer := testAddr("merchant"), testAddr("customer")
+	channelID, _, walletKey := createTestOrder(t, ctx, k, merchant, customer, 10)
+
+	balance := NewChannelBalance(channelID, 1, stake(5), stake(15))
+	res := handler(ctx, NewMsgClaimOrder(merchant, channelID, 1, stake(5), stake(15), signTestBalance(balance, walletKey)))
+	require.True(t, res.IsOK(), res.Log)
+
+	// With the escrow account short, the merchant could be paid but the customer could not
+	escrowAddr := k.SupplyKeeper.GetModuleAccount(ctx, EscrowAccountName).GetAddress()
+	_, err := k.CoinKeeper.SubtractCoins(ctx, escrowAddr, stake(10))
+	require.Nil(t, err)
+
+	// The failed payout is discarded as a whole and the close stays queued
+	EndBlocker(ctx.WithBlockHeight(101), k)
+	require.True(t, k.GetEscrow(ctx, channelID).IsClosing())
+	require.True(t, k.CoinKeeper.GetCoins(ctx, merchant).IsZero())
+	require.True(t, k.CoinKeeper.GetCoins(ctx, customer).IsZero())
+	require.Equal(t, stake(10), k.CoinKeeper.GetCoins(ctx, escrowAddr))
+
+	// and is retried by later blocks
+	fundModuleAccount(t, ctx, k, EscrowAccountName, stake(10))
+	EndBlocker(ctx.WithBlockHeight(102), k)
+	require.False(t, k.IsEscrowPresent(ctx, channelID))
+	require.Equal(t, stake(5), k.CoinKeeper.GetCoins(ctx, merchant))
+	require.Equal(t, stake(15), k.CoinKeeper.GetCoins(ctx, customer))
+}
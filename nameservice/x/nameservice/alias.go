@@ -6,9 +6,13 @@ import (
 )
 
 const (
-	ModuleName = types.ModuleName
-	RouterKey  = types.RouterKey
-	StoreKey   = types.StoreKey
+	ModuleName         = types.ModuleName
+	RouterKey          = types.RouterKey
+	StoreKey           = types.StoreKey
+	EscrowAccountName  = types.EscrowAccountName
+	AuctionAccountName = types.AuctionAccountName
+	DefaultParamspace  = types.DefaultParamspace
+	StoreVersion       = types.StoreVersion
 )
 
 var (
@@ -17,10 +21,38 @@ var (
 	NewMsgBuyName    = types.NewMsgBuyName
 	NewMsgSetName    = types.NewMsgSetName
 	NewMsgDeleteName = types.NewMsgDeleteName
+	NewMsgRenewName  = types.NewMsgRenewName
 
-	NewMsgCreateOrder = types.NewMsgCreateOrder
-	NewMsgFillOrder   = types.NewMsgFillOrder
-	NewEscrow         = types.NewEscrow
+	NewMsgCommitBid = types.NewMsgCommitBid
+	NewMsgRevealBid = types.NewMsgRevealBid
+	NewAuction      = types.NewAuction
+	NewBidHash      = types.NewBidHash
+
+	RegisterInvariants = keeper.RegisterInvariants
+	AllInvariants      = keeper.AllInvariants
+
+	NewMsgCreateOrder   = types.NewMsgCreateOrder
+	NewMsgFillOrder     = types.NewMsgFillOrder
+	NewMsgClaimOrder    = types.NewMsgClaimOrder
+	NewMsgBatchClaim    = types.NewMsgBatchClaim
+	NewBatchClaim       = types.NewBatchClaim
+	NewMsgDisputeClose  = types.NewMsgDisputeClose
+	NewMsgCustomerClose = types.NewMsgCustomerClose
+	NewMsgRevokeClose   = types.NewMsgRevokeClose
+	NewMsgMutualClose   = types.NewMsgMutualClose
+	NewRevocation       = types.NewRevocation
+	NewPossessionProof  = types.NewPossessionProof
+	NewEscrow           = types.NewEscrow
+	NewChannelBalance   = types.NewChannelBalance
+	NewChannelID        = types.NewChannelID
+
+	ValidateSignature = types.ValidateSignature
+	ValidatePubKey    = types.ValidatePubKey
+
+	ValidateAggregateSignature = types.ValidateAggregateSignature
+
+	NewParams     = types.NewParams
+	DefaultParams = types.DefaultParams
 
 	NewWhois      = types.NewWhois
 	ModuleCdc     = types.ModuleCdc
@@ -32,13 +64,36 @@ type (
 	MsgSetName      = types.MsgSetName
 	MsgBuyName      = types.MsgBuyName
 	MsgDeleteName   = types.MsgDeleteName
+	MsgRenewName    = types.MsgRenewName
 	QueryResResolve = types.QueryResResolve
 	QueryResNames   = types.QueryResNames
 
-	MsgCreateOrder = types.MsgCreateOrder
-	MsgFillOrder   = types.MsgFillOrder
-	QueryResOrder  = types.QueryResOrders
-	Escrow         = types.Escrow
+	MsgCommitBid  = types.MsgCommitBid
+	MsgRevealBid  = types.MsgRevealBid
+	Auction       = types.Auction
+	SealedBid     = types.SealedBid
+	BidCommitment = types.BidCommitment
+
+	MsgCreateOrder   = types.MsgCreateOrder
+	MsgFillOrder     = types.MsgFillOrder
+	MsgClaimOrder    = types.MsgClaimOrder
+	MsgBatchClaim    = types.MsgBatchClaim
+	BatchClaim       = types.BatchClaim
+	BatchClaimResult = types.BatchClaimResult
+	MsgDisputeClose  = types.MsgDisputeClose
+	MsgCustomerClose = types.MsgCustomerClose
+	MsgRevokeClose   = types.MsgRevokeClose
+	MsgMutualClose   = types.MsgMutualClose
+	Revocation       = types.Revocation
+	PossessionProof  = types.PossessionProof
+	QueryResOrder    = types.QueryResOrders
+	Escrow           = types.Escrow
+	ChannelBalance   = types.ChannelBalance
+	PendingClose     = types.PendingClose
+
+	Bls12381PubKey    = types.Bls12381PubKey
+	Bls12381Signature = types.Bls12381Signature
 
-	Whois = types.Whois
+	Whois  = types.Whois
+	Params = types.Params
 )
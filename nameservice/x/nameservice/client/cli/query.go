@@ -10,6 +10,12 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const (
+	flagStatus   = "status"
+	flagStartKey = "start-key"
+	flagLimit    = "limit"
+)
+
 func GetQueryCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 	nameserviceQueryCmd := &cobra.Command{
 		Use:                        types.ModuleName,
@@ -19,29 +25,249 @@ func GetQueryCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 		RunE:                       client.ValidateCmd,
 	}
 	nameserviceQueryCmd.AddCommand(client.GetCommands(
+		GetCmdResolveName(storeKey, cdc),
+		GetCmdWhois(storeKey, cdc),
+		GetCmdNames(storeKey, cdc),
+		GetCmdAuction(storeKey, cdc),
+		GetCmdOrder(storeKey, cdc),
 		GetCmdOrders(storeKey, cdc),
+		GetCmdMerchantOrders(storeKey, cdc),
+		GetCmdCustomerOrders(storeKey, cdc),
+		GetCmdParams(storeKey, cdc),
 	)...)
 	return nameserviceQueryCmd
 }
 
-// GetCmdOrders queries all orders
-func GetCmdOrders(queryRoute string, cdc *codec.Codec) *cobra.Command {
+// GetCmdResolveName queries information about a name
+func GetCmdResolveName(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
-		Use:   "orders",
-		Short: "orders",
-		// Args: cobra.ExactArgs(1),
+		Use:   "resolve [name]",
+		Short: "Resolve a name to its value",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			name := args[0]
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/resolve/%s", queryRoute, name), nil)
+			if err != nil {
+				fmt.Printf("could not resolve name - %s \n", name)
+				return nil
+			}
+
+			var out types.QueryResResolve
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdWhois queries the owner, value and price of a name
+func GetCmdWhois(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "whois [name]",
+		Short: "Query the owner, value and price of a name",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			name := args[0]
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/whois/%s", queryRoute, name), nil)
+			if err != nil {
+				fmt.Printf("could not resolve whois - %s \n", name)
+				return nil
+			}
+
+			var out types.Whois
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdAuction queries the open auction for a name
+func GetCmdAuction(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "auction [name]",
+		Short: "Query the open auction for a name and its sealed bids",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			name := args[0]
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/auction/%s", queryRoute, name), nil)
+			if err != nil {
+				fmt.Printf("could not find auction - %s \n", name)
+				return nil
+			}
+
+			var out types.Auction
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdNames queries a page of all owned names
+func GetCmdNames(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "names",
+		Short: "Query all owned names",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			startKey, err := cmd.Flags().GetString(flagStartKey)
+			if err != nil {
+				return err
+			}
+			limit, err := cmd.Flags().GetInt(flagLimit)
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryNamesParams(startKey, limit))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/names", queryRoute), bz)
+			if err != nil {
+				fmt.Printf("could not get query names\n")
+				return nil
+			}
+
+			var out types.QueryResNames
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+	cmd.Flags().String(flagStartKey, "", "Name to start the page at, as returned in next_key")
+	cmd.Flags().Int(flagLimit, types.DefaultNamesLimit, "Maximum number of names to return")
+	return cmd
+}
+
+// GetCmdOrder queries a single order by its channel ID
+func GetCmdOrder(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "order [channel-id]",
+		Short: "Query an order by its channel ID",
+		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			channelID := args[0]
 
-			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/orders", queryRoute), nil)
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/order/%s", queryRoute, channelID), nil)
 			if err != nil {
-				fmt.Printf("could not get query orders\n")
+				fmt.Printf("could not find order - %s \n", channelID)
 				return nil
 			}
 
-			var out types.QueryResOrders
+			var out types.Escrow
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdParams queries the module params
+func GetCmdParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "params",
+		Short: "Query the current nameservice parameters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/params", queryRoute), nil)
+			if err != nil {
+				return err
+			}
+
+			var out types.Params
 			cdc.MustUnmarshalJSON(res, &out)
 			return cliCtx.PrintOutput(out)
 		},
 	}
 }
+
+// GetCmdOrders queries all orders
+func GetCmdOrders(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "orders",
+		Short: "Query all orders",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return queryOrders(cmd, cdc, fmt.Sprintf("custom/%s/orders", queryRoute))
+		},
+	}
+	addOrdersFlags(cmd)
+	return cmd
+}
+
+// GetCmdMerchantOrders queries the orders created by a merchant
+func GetCmdMerchantOrders(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "merchant-orders [address]",
+		Short: "Query the orders created by a merchant",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return queryOrders(cmd, cdc, fmt.Sprintf("custom/%s/orders/merchant/%s", queryRoute, args[0]))
+		},
+	}
+	addOrdersFlags(cmd)
+	return cmd
+}
+
+// GetCmdCustomerOrders queries the orders filled by a customer
+func GetCmdCustomerOrders(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "customer-orders [address]",
+		Short: "Query the orders filled by a customer",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return queryOrders(cmd, cdc, fmt.Sprintf("custom/%s/orders/customer/%s", queryRoute, args[0]))
+		},
+	}
+	addOrdersFlags(cmd)
+	return cmd
+}
+
+// addOrdersFlags adds the filter and pagination flags of an orders query to cmd
+func addOrdersFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagStatus, "", "Only show orders with this status (open|filled|closing)")
+	cmd.Flags().String(flagStartKey, "", "Channel ID to start the page at, as returned in next_key")
+	cmd.Flags().Int(flagLimit, types.DefaultOrdersLimit, "Maximum number of orders to return")
+}
+
+// queryOrders runs an orders query on route with the filter and pagination flags of cmd as its params
+func queryOrders(cmd *cobra.Command, cdc *codec.Codec, route string) error {
+	cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+	status, err := cmd.Flags().GetString(flagStatus)
+	if err != nil {
+		return err
+	}
+	startKey, err := cmd.Flags().GetString(flagStartKey)
+	if err != nil {
+		return err
+	}
+	limit, err := cmd.Flags().GetInt(flagLimit)
+	if err != nil {
+		return err
+	}
+
+	bz, err := cdc.MarshalJSON(types.NewQueryOrdersParams(status, startKey, limit))
+	if err != nil {
+		return err
+	}
+
+	res, _, err := cliCtx.QueryWithData(route, bz)
+	if err != nil {
+		fmt.Printf("could not get query orders\n")
+		return nil
+	}
+
+	var out types.QueryResOrders
+	cdc.MustUnmarshalJSON(res, &out)
+	return cliCtx.PrintOutput(out)
+}
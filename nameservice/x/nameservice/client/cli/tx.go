@@ -1,6 +1,11 @@
 package cli
 
 import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
 	"github.com/spf13/cobra"
 
 	"github.com/cosmos/cosmos-sdk/client"
@@ -22,32 +27,297 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 	}
 
 	nameserviceTxCmd.AddCommand(client.PostCommands(
+		GetCmdBuyName(cdc),
+		GetCmdSetName(cdc),
+		GetCmdDeleteName(cdc),
+		GetCmdRenewName(cdc),
+		GetCmdCommitBid(cdc),
+		GetCmdRevealBid(cdc),
 		GetCmdCreateOrder(cdc),
+		GetCmdFillOrder(storeKey, cdc),
+		GetCmdClaimOrder(cdc),
+		GetCmdBatchClaim(cdc),
+		GetCmdDisputeClose(cdc),
+		GetCmdCustomerClose(cdc),
+		GetCmdRevokeClose(cdc),
+		GetCmdMutualClose(cdc),
 	)...)
 
 	return nameserviceTxCmd
 }
 
+// GetCmdBuyName is the CLI command for sending a BuyName transaction
+func GetCmdBuyName(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "buy-name [name] [amount]",
+		Short: "bid for existing name or claim new name",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			coins, err := sdk.ParseCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgBuyName(args[0], coins, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdSetName is the CLI command for sending a SetName transaction
+func GetCmdSetName(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-name [name] [value]",
+		Short: "set the value associated with a name that you own",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			msg := types.NewMsgSetName(args[0], args[1], cliCtx.GetFromAddress())
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdDeleteName is the CLI command for sending a DeleteName transaction
+func GetCmdDeleteName(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete-name [name]",
+		Short: "delete the name that you own along with its associated fields",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			msg := types.NewMsgDeleteName(args[0], cliCtx.GetFromAddress())
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdRenewName is the CLI command for sending a RenewName transaction
+func GetCmdRenewName(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "renew-name [name]",
+		Short: "extend the registration of a name that you own by paying the renewal fee",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			msg := types.NewMsgRenewName(args[0], cliCtx.GetFromAddress())
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdCommitBid is the CLI command for sending a CommitBid transaction
+func GetCmdCommitBid(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "commit-bid [name] [amount] [salt-hex] [deposit]",
+		Short: "commit a sealed bid on a name, starting its auction if there is none",
+		Long: `Commit a sealed bid on a name, starting its auction if there is none. Only the hash of the
+amount and salt is sent, so keep both to reveal the bid once the auction stops taking bids.
+The deposit must cover the amount, and hides it as long as it is larger.`,
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			amount, err := sdk.ParseCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			salt, err := hex.DecodeString(args[2])
+			if err != nil {
+				return err
+			}
+
+			deposit, err := sdk.ParseCoins(args[3])
+			if err != nil {
+				return err
+			}
+
+			bidder := cliCtx.GetFromAddress()
+			bidHash := types.NewBidHash(args[0], bidder, amount, salt)
+			msg := types.NewMsgCommitBid(args[0], bidder, bidHash, deposit)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdRevealBid is the CLI command for sending a RevealBid transaction
+func GetCmdRevealBid(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reveal-bid [name] [amount] [salt-hex]",
+		Short: "reveal a sealed bid on a name with the amount and salt it was committed with",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			amount, err := sdk.ParseCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			salt, err := hex.DecodeString(args[2])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRevealBid(args[0], cliCtx.GetFromAddress(), amount, salt)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
 func GetCmdCreateOrder(cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
-		Use:   "create-order [channel_state] [channel_token] [amount]",
+		Use:   "create-order [channel-state-hex] [channel-token-hex] [proof-of-possession-hex] [amount]",
 		Short: "create an order and place funds in escrow",
-		Args:  cobra.ExactArgs(3),
+		Long: `Create an order and place funds in escrow. The proof of possession is a Bls12-381 signature
+made with the channel state key over the merchant address and chain ID.`,
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			channelState, err := hex.DecodeString(args[0])
+			if err != nil {
+				return err
+			}
+
+			channelToken, err := hex.DecodeString(args[1])
+			if err != nil {
+				return err
+			}
+
+			proof, err := hex.DecodeString(args[2])
+			if err != nil {
+				return err
+			}
+
+			coins, err := sdk.ParseCoins(args[3])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCreateOrder(cliCtx.GetFromAddress(), channelState, channelToken, proof, coins)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdFillOrder fills an order by placing the customer's funds in escrow
+func GetCmdFillOrder(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "fill-order [merchant-or-channel-id] [wallet-commit-hex] [amount]",
+		Short: "fill an order and place funds in escrow",
+		Long: `Fill an order and place funds in escrow. The order is given by its channel ID,
+or by the address of a merchant with exactly one open order.`,
+		Args: cobra.ExactArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
 			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
 
+			channelID, err := resolveChannelID(cliCtx, queryRoute, args[0])
+			if err != nil {
+				return err
+			}
+
+			walletCommit, err := hex.DecodeString(args[1])
+			if err != nil {
+				return err
+			}
 			coins, err := sdk.ParseCoins(args[2])
 			if err != nil {
 				return err
 			}
 
-			// var blsPubKey, commitPubKey types.Bls12381PubKey // [96]byte
-			// copy(blsPubKey[:], []byte(args[0]))
-			// copy(commitPubKey[:], []byte(args[1]))
+			msg := types.NewMsgFillOrder(channelID, cliCtx.GetFromAddress(), walletCommit, coins)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdClaimOrder is the CLI command for sending a ClaimOrder transaction
+func GetCmdClaimOrder(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "claim-order [channel-id] [nonce] [merchant-balance] [customer-balance] [customer-signature-hex]",
+		Short: "close a filled order as its merchant on a balance signed by the customer",
+		Long: `Close a filled order as its merchant on a balance signed by the customer with the wallet commit key.
+The escrow is paid out once the dispute period ends, unless the customer disputes it with a newer balance.`,
+		Args: cobra.ExactArgs(5),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			nonce, merchantBalance, customerBalance, err := parseChannelBalance(args[1], args[2], args[3])
+			if err != nil {
+				return err
+			}
+
+			signature, err := hex.DecodeString(args[4])
+			if err != nil {
+				return err
+			}
 
-			msg := types.NewMsgCreateOrder(cliCtx.GetFromAddress(), args[0], args[1], coins)
+			msg := types.NewMsgClaimOrder(cliCtx.GetFromAddress(), args[0], nonce, merchantBalance, customerBalance, signature)
 			err = msg.ValidateBasic()
 			if err != nil {
 				return err
@@ -58,8 +328,227 @@ func GetCmdCreateOrder(cdc *codec.Codec) *cobra.Command {
 	}
 }
 
-// func GetCmdFillOrder(cdc *codec.Codec) *cobra.Command {
-// 	return &cobra.Command{
-// 		Use: "fill-order "
-// 	},
-// }
+// GetCmdBatchClaim is the CLI command for sending a BatchClaim transaction
+func GetCmdBatchClaim(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "batch-claim [claims-file] [aggregate-signature-hex]",
+		Short: "close many filled orders as their merchant with one aggregate customer signature",
+		Long: `Close many filled orders as their merchant. The claims file holds a JSON list of claims, each with a
+channelID, nonce, merchantBalance and customerBalance, and the signature aggregates the signatures each
+customer made over their channel's balance with the wallet commit key.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			bz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			var claims []types.BatchClaim
+			err = cdc.UnmarshalJSON(bz, &claims)
+			if err != nil {
+				return err
+			}
+
+			signature, err := hex.DecodeString(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgBatchClaim(cliCtx.GetFromAddress(), claims, signature)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdDisputeClose is the CLI command for sending a DisputeClose transaction
+func GetCmdDisputeClose(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "dispute-close [channel-id] [nonce] [merchant-balance] [customer-balance] [merchant-signature-hex]",
+		Short: "dispute a merchant close as the customer with a newer balance signed by the merchant",
+		Long: `Dispute a merchant close within its dispute period with a newer balance the merchant signed with the
+channel state key. The merchant is punished by paying the whole escrow to the customer.`,
+		Args: cobra.ExactArgs(5),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			nonce, merchantBalance, customerBalance, err := parseChannelBalance(args[1], args[2], args[3])
+			if err != nil {
+				return err
+			}
+
+			signature, err := hex.DecodeString(args[4])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgDisputeClose(cliCtx.GetFromAddress(), args[0], nonce, merchantBalance, customerBalance, signature)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdCustomerClose is the CLI command for sending a CustomerClose transaction
+func GetCmdCustomerClose(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "customer-close [channel-id] [nonce] [merchant-balance] [customer-balance] [merchant-signature-hex]",
+		Short: "close a filled order as its customer on a balance signed by the merchant",
+		Long: `Close a filled order as its customer on a balance the merchant signed with the channel state key.
+The escrow is paid out once the dispute period ends, unless the merchant revokes the close.`,
+		Args: cobra.ExactArgs(5),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			nonce, merchantBalance, customerBalance, err := parseChannelBalance(args[1], args[2], args[3])
+			if err != nil {
+				return err
+			}
+
+			signature, err := hex.DecodeString(args[4])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCustomerClose(cliCtx.GetFromAddress(), args[0], nonce, merchantBalance, customerBalance, signature)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdRevokeClose is the CLI command for sending a RevokeClose transaction
+func GetCmdRevokeClose(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke-close [channel-id] [revocation-token-hex]",
+		Short: "punish a customer close on a revoked balance as the merchant",
+		Long: `Punish a customer close within its dispute period with the revocation token the customer signed
+with the wallet commit key for the closing nonce. The whole escrow is paid to the merchant.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			token, err := hex.DecodeString(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRevokeClose(cliCtx.GetFromAddress(), args[0], token)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdMutualClose is the CLI command for sending a MutualClose transaction
+func GetCmdMutualClose(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "mutual-close [channel-id] [customer-address] [merchant-balance] [customer-balance]",
+		Short: "close a filled order right away as its merchant and customer together",
+		Long: `Close a filled order right away as its merchant and customer together. Both must sign the
+transaction, so generate it from the merchant with --generate-only and sign it by both before broadcasting.`,
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			customer, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			merchantBalance, err := sdk.ParseCoins(args[2])
+			if err != nil {
+				return err
+			}
+
+			customerBalance, err := sdk.ParseCoins(args[3])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgMutualClose(cliCtx.GetFromAddress(), customer, args[0], merchantBalance, customerBalance)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// parseChannelBalance parses the nonce and balances of a channel balance given as arguments
+func parseChannelBalance(nonceArg string, merchantBalanceArg string, customerBalanceArg string) (uint64, sdk.Coins, sdk.Coins, error) {
+	nonce, err := strconv.ParseUint(nonceArg, 10, 64)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	merchantBalance, err := sdk.ParseCoins(merchantBalanceArg)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	customerBalance, err := sdk.ParseCoins(customerBalanceArg)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	return nonce, merchantBalance, customerBalance, nil
+}
+
+// resolveChannelID returns arg if it is a channel ID, or the channel ID of the only open order of arg if it is a merchant address
+func resolveChannelID(cliCtx context.CLIContext, queryRoute string, arg string) (string, error) {
+	merchant, err := sdk.AccAddressFromBech32(arg)
+	if err != nil {
+		return arg, nil
+	}
+
+	bz, err := cliCtx.Codec.MarshalJSON(types.NewQueryOrdersParams(types.StatusOpen, "", 1))
+	if err != nil {
+		return "", err
+	}
+
+	res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/orders/merchant/%s", queryRoute, merchant), bz)
+	if err != nil {
+		return "", err
+	}
+
+	var out types.QueryResOrders
+	cliCtx.Codec.MustUnmarshalJSON(res, &out)
+	switch {
+	case len(out.Orders) == 0:
+		return "", fmt.Errorf("merchant %s has no open orders", merchant)
+	case out.NextKey != "":
+		return "", fmt.Errorf("merchant %s has more than one open order, use a channel ID instead", merchant)
+	}
+	return out.Orders[0].ChannelID, nil
+}
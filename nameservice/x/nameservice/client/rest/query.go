@@ -3,16 +3,48 @@ package rest
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice/internal/types"
+	"github.com/gorilla/mux"
 
 	"github.com/cosmos/cosmos-sdk/types/rest"
 )
 
-func ordersHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+func paramsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/params", storeName), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func resolveNameHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		paramType := vars[restName]
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/resolve/%s", storeName, paramType), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func whoIsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		paramType := vars[restName]
 
-		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/orders", storeName), nil)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/whois/%s", storeName, paramType), nil)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
 			return
@@ -21,3 +53,112 @@ func ordersHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
+
+func auctionHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		paramType := vars[restName]
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/auction/%s", storeName, paramType), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// namesHandler runs a names query paginated by the request's query string
+func namesHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		limit := 0
+		if query.Get("limit") != "" {
+			var err error
+			limit, err = strconv.Atoi(query.Get("limit"))
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(types.NewQueryNamesParams(query.Get("start_key"), limit))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/names", storeName), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func orderHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		channelID := vars[restChannelID]
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/order/%s", storeName, channelID), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func ordersHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		queryOrders(w, r, cliCtx, fmt.Sprintf("custom/%s/orders", storeName))
+	}
+}
+
+func merchantOrdersHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		queryOrders(w, r, cliCtx, fmt.Sprintf("custom/%s/orders/merchant/%s", storeName, vars[restAddress]))
+	}
+}
+
+func customerOrdersHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		queryOrders(w, r, cliCtx, fmt.Sprintf("custom/%s/orders/customer/%s", storeName, vars[restAddress]))
+	}
+}
+
+// queryOrders runs an orders query on route, filtered and paginated by the request's query string
+func queryOrders(w http.ResponseWriter, r *http.Request, cliCtx context.CLIContext, route string) {
+	query := r.URL.Query()
+	limit := 0
+	if query.Get("limit") != "" {
+		var err error
+		limit, err = strconv.Atoi(query.Get("limit"))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	params := types.NewQueryOrdersParams(query.Get("status"), query.Get("start_key"), limit)
+	bz, err := cliCtx.Codec.MarshalJSON(params)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, _, err := cliCtx.QueryWithData(route, bz)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	rest.PostProcessResponse(w, cliCtx, res)
+}
@@ -9,11 +9,34 @@ import (
 )
 
 const (
-	restName = "name"
+	restName      = "name"
+	restChannelID = "channelID"
+	restAddress   = "address"
 )
 
 // RegisterRoutes - Central function to define routes that get registered by the main application
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, storeName string) {
+	r.HandleFunc(fmt.Sprintf("/%s/params", storeName), paramsHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/names", storeName), namesHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/names", storeName), buyNameHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/names", storeName), setNameHandler(cliCtx)).Methods("PUT")
+	r.HandleFunc(fmt.Sprintf("/%s/names", storeName), deleteNameHandler(cliCtx)).Methods("DELETE")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}", storeName, restName), resolveNameHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/whois", storeName, restName), whoIsHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/renew", storeName, restName), renewNameHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/auctions/{%s}", storeName, restName), auctionHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/auctions/{%s}/bids", storeName, restName), commitBidHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/auctions/{%s}/reveal", storeName, restName), revealBidHandler(cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/%s/orders", storeName), ordersHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/orders", storeName), createOrderHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/orders/claims", storeName), batchClaimHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/orders/merchant/{%s}", storeName, restAddress), merchantOrdersHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/orders/customer/{%s}", storeName, restAddress), customerOrdersHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/orders/{%s}", storeName, restChannelID), orderHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/orders/{%s}/fill", storeName, restChannelID), fillOrderHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/orders/{%s}/claim", storeName, restChannelID), claimOrderHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/orders/{%s}/dispute", storeName, restChannelID), disputeCloseHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/orders/{%s}/close", storeName, restChannelID), customerCloseHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/orders/{%s}/revoke", storeName, restChannelID), revokeCloseHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/orders/{%s}/mutual-close", storeName, restChannelID), mutualCloseHandler(cliCtx)).Methods("POST")
 }
@@ -1,22 +1,285 @@
 package rest
 
 import (
+	"encoding/hex"
 	"net/http"
+	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice/internal/types"
+	"github.com/gorilla/mux"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/rest"
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
 )
 
+type buyNameReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	Name    string       `json:"name"`
+	Amount  string       `json:"amount"`
+	Buyer   string       `json:"buyer"`
+}
+
+func buyNameHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req buyNameReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Buyer)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		coins, err := sdk.ParseCoins(req.Amount)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgBuyName(req.Name, coins, addr)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type setNameReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	Name    string       `json:"name"`
+	Value   string       `json:"value"`
+	Owner   string       `json:"owner"`
+}
+
+func setNameHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req setNameReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Owner)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgSetName(req.Name, req.Value, addr)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type deleteNameReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	Name    string       `json:"name"`
+	Owner   string       `json:"owner"`
+}
+
+func deleteNameHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req deleteNameReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Owner)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgDeleteName(req.Name, addr)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type renewNameReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	Owner   string       `json:"owner"`
+}
+
+func renewNameHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req renewNameReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Owner)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		vars := mux.Vars(r)
+		msg := types.NewMsgRenewName(vars[restName], addr)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type commitBidReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	Bidder  string       `json:"bidder"`
+	BidHash string       `json:"bid_hash"` // hex encoded sha256 of the bid commitment
+	Deposit string       `json:"deposit"`
+}
+
+func commitBidHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req commitBidReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Bidder)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		bidHash, err := hex.DecodeString(req.BidHash)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		coins, err := sdk.ParseCoins(req.Deposit)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		vars := mux.Vars(r)
+		msg := types.NewMsgCommitBid(vars[restName], addr, bidHash, coins)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type revealBidReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	Bidder  string       `json:"bidder"`
+	Amount  string       `json:"amount"`
+	Salt    string       `json:"salt"` // hex encoded
+}
+
+func revealBidHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req revealBidReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Bidder)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		coins, err := sdk.ParseCoins(req.Amount)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		salt, err := hex.DecodeString(req.Salt)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		vars := mux.Vars(r)
+		msg := types.NewMsgRevealBid(vars[restName], addr, coins, salt)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
 type createOrderReq struct {
 	BaseReq      rest.BaseReq `json:"base_req"`
 	Merchant     string       `json:"merchant"`
-	ChannelState string       `json:"channelState"`
-	ChannelToken string       `json:"channelToken"`
-	Amount       string       `json:"amount"`
+	ChannelState string       `json:"channelState"` // hex encoded, 96 bytes
+	ChannelToken string       `json:"channelToken"` // hex encoded
+	// hex encoded Bls12-381 signature over the merchant address and chain ID, made with the channel state key
+	ProofOfPossession string `json:"proofOfPossession"`
+	Amount            string `json:"amount"`
 }
 
 func createOrderHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -25,6 +288,7 @@ func createOrderHandler(cliCtx context.CLIContext) http.HandlerFunc {
 
 		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
 		}
 
 		baseReq := req.BaseReq.Sanitize()
@@ -38,11 +302,23 @@ func createOrderHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		// var owner, commitPubKey [96]byte
-		// blsPubKeyBytes := []byte(req.Owner)
-		// commitPubKeyBytes := []byte(req.CommitPubKey)
-		// copy(owner[:], blsPubKeyBytes)
-		// copy(commitPubKey[:], commitPubKeyBytes)
+		channelState, err := hex.DecodeString(req.ChannelState)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		channelToken, err := hex.DecodeString(req.ChannelToken)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		proof, err := hex.DecodeString(req.ProofOfPossession)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
 
 		coins, err := sdk.ParseCoins(req.Amount)
 		if err != nil {
@@ -51,7 +327,7 @@ func createOrderHandler(cliCtx context.CLIContext) http.HandlerFunc {
 		}
 
 		// Message time
-		msg := types.NewMsgCreateOrder(addr, req.ChannelState, req.ChannelToken, coins)
+		msg := types.NewMsgCreateOrder(addr, channelState, channelToken, proof, coins)
 		err = msg.ValidateBasic()
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -61,3 +337,382 @@ func createOrderHandler(cliCtx context.CLIContext) http.HandlerFunc {
 		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 	}
 }
+
+type fillOrderReq struct {
+	BaseReq      rest.BaseReq `json:"base_req"`
+	Customer     string       `json:"customer"`
+	WalletCommit string       `json:"walletCommit"` // hex encoded, 96 bytes
+	Amount       string       `json:"amount"`
+}
+
+func fillOrderHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req fillOrderReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Customer)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		walletCommit, err := hex.DecodeString(req.WalletCommit)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		coins, err := sdk.ParseCoins(req.Amount)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		vars := mux.Vars(r)
+		msg := types.NewMsgFillOrder(vars[restChannelID], addr, walletCommit, coins)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type claimOrderReq struct {
+	BaseReq         rest.BaseReq `json:"base_req"`
+	Merchant        string       `json:"merchant"`
+	Nonce           string       `json:"nonce"`
+	MerchantBalance string       `json:"merchantBalance"`
+	CustomerBalance string       `json:"customerBalance"`
+	Signature       string       `json:"signature"` // hex encoded Bls12-381 signature over the channel balance, made by the customer with the wallet commit key
+}
+
+func claimOrderHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req claimOrderReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Merchant)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		nonce, merchantBalance, customerBalance, ok := parseChannelBalance(w, req.Nonce, req.MerchantBalance, req.CustomerBalance)
+		if !ok {
+			return
+		}
+
+		signature, err := hex.DecodeString(req.Signature)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		vars := mux.Vars(r)
+		msg := types.NewMsgClaimOrder(addr, vars[restChannelID], nonce, merchantBalance, customerBalance, signature)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type batchClaimReq struct {
+	BaseReq  rest.BaseReq       `json:"base_req"`
+	Merchant string             `json:"merchant"`
+	Claims   []types.BatchClaim `json:"claims"`
+	// hex encoded aggregate of the customers' Bls12-381 signatures over each channel balance
+	Signature string `json:"signature"`
+}
+
+func batchClaimHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req batchClaimReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Merchant)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		signature, err := hex.DecodeString(req.Signature)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgBatchClaim(addr, req.Claims, signature)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type disputeCloseReq struct {
+	BaseReq         rest.BaseReq `json:"base_req"`
+	Customer        string       `json:"customer"`
+	Nonce           string       `json:"nonce"`
+	MerchantBalance string       `json:"merchantBalance"`
+	CustomerBalance string       `json:"customerBalance"`
+	Signature       string       `json:"signature"` // hex encoded Bls12-381 signature over the channel balance, made by the merchant with the channel state key
+}
+
+func disputeCloseHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req disputeCloseReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Customer)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		nonce, merchantBalance, customerBalance, ok := parseChannelBalance(w, req.Nonce, req.MerchantBalance, req.CustomerBalance)
+		if !ok {
+			return
+		}
+
+		signature, err := hex.DecodeString(req.Signature)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		vars := mux.Vars(r)
+		msg := types.NewMsgDisputeClose(addr, vars[restChannelID], nonce, merchantBalance, customerBalance, signature)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type customerCloseReq struct {
+	BaseReq         rest.BaseReq `json:"base_req"`
+	Customer        string       `json:"customer"`
+	Nonce           string       `json:"nonce"`
+	MerchantBalance string       `json:"merchantBalance"`
+	CustomerBalance string       `json:"customerBalance"`
+	Signature       string       `json:"signature"` // hex encoded Bls12-381 signature over the channel balance, made by the merchant with the channel state key
+}
+
+func customerCloseHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req customerCloseReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Customer)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		nonce, merchantBalance, customerBalance, ok := parseChannelBalance(w, req.Nonce, req.MerchantBalance, req.CustomerBalance)
+		if !ok {
+			return
+		}
+
+		signature, err := hex.DecodeString(req.Signature)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		vars := mux.Vars(r)
+		msg := types.NewMsgCustomerClose(addr, vars[restChannelID], nonce, merchantBalance, customerBalance, signature)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type revokeCloseReq struct {
+	BaseReq  rest.BaseReq `json:"base_req"`
+	Merchant string       `json:"merchant"`
+	// hex encoded Bls12-381 signature over the revoked nonce, made by the customer with the wallet commit key
+	RevocationToken string `json:"revocationToken"`
+}
+
+func revokeCloseHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req revokeCloseReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		addr, err := sdk.AccAddressFromBech32(req.Merchant)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		token, err := hex.DecodeString(req.RevocationToken)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		vars := mux.Vars(r)
+		msg := types.NewMsgRevokeClose(addr, vars[restChannelID], token)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type mutualCloseReq struct {
+	BaseReq         rest.BaseReq `json:"base_req"`
+	Merchant        string       `json:"merchant"`
+	Customer        string       `json:"customer"`
+	MerchantBalance string       `json:"merchantBalance"`
+	CustomerBalance string       `json:"customerBalance"`
+}
+
+func mutualCloseHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req mutualCloseReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		merchant, err := sdk.AccAddressFromBech32(req.Merchant)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		customer, err := sdk.AccAddressFromBech32(req.Customer)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		merchantBalance, err := sdk.ParseCoins(req.MerchantBalance)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		customerBalance, err := sdk.ParseCoins(req.CustomerBalance)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// The generated transaction must be signed by both the merchant and the customer
+		vars := mux.Vars(r)
+		msg := types.NewMsgMutualClose(merchant, customer, vars[restChannelID], merchantBalance, customerBalance)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+// parseChannelBalance parses the nonce and balances of a channel balance request, writing an error response on failure
+func parseChannelBalance(w http.ResponseWriter, nonceArg string, merchantBalanceArg string, customerBalanceArg string) (uint64, sdk.Coins, sdk.Coins, bool) {
+	nonce, err := strconv.ParseUint(nonceArg, 10, 64)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return 0, nil, nil, false
+	}
+
+	merchantBalance, err := sdk.ParseCoins(merchantBalanceArg)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return 0, nil, nil, false
+	}
+
+	customerBalance, err := sdk.ParseCoins(customerBalanceArg)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return 0, nil, nil, false
+	}
+
+	return nonce, merchantBalance, customerBalance, true
+}
@@ -0,0 +1,77 @@
+package nameservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+)
+
+// createTestInput returns a context at height one and a nameservice keeper backed by in-memory stores
+func createTestInput(t *testing.T) (sdk.Context, Keeper) {
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keySupply := sdk.NewKVStoreKey(supply.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+	keyNameservice := sdk.NewKVStoreKey(StoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySupply, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	ms.MountStoreWithDB(keyNameservice, sdk.StoreTypeIAVL, db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	auth.RegisterCodec(cdc)
+	supply.RegisterCodec(cdc)
+	RegisterCodec(cdc)
+	sdk.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "nameservice", Height: 1}, false, log.NewNopLogger())
+
+	maccPerms := map[string][]string{
+		auth.FeeCollectorName: nil,
+		EscrowAccountName:     nil,
+		AuctionAccountName:    {supply.Burner},
+	}
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams, params.DefaultCodespace)
+	ak := auth.NewAccountKeeper(cdc, keyAcc, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	bk := bank.NewBaseKeeper(ak, pk.Subspace(bank.DefaultParamspace), bank.DefaultCodespace, nil)
+	sk := supply.NewKeeper(cdc, keySupply, ak, bk, maccPerms)
+	sk.SetSupply(ctx, supply.NewSupply(sdk.NewCoins()))
+
+	k := NewKeeper(bk, sk, keyNameservice, cdc, pk.Subspace(DefaultParamspace))
+	k.SetParams(ctx, DefaultParams())
+	return ctx, k
+}
+
+// testAddr returns a deterministic account address derived from seed
+func testAddr(seed string) sdk.AccAddress {
+	return sdk.AccAddress(crypto.AddressHash([]byte(seed)))
+}
+
+// fundAccount gives an account coins, or a module account if addr is the address of one
+func fundAccount(t *testing.T, ctx sdk.Context, k Keeper, addr sdk.AccAddress, coins sdk.Coins) {
+	_, err := k.CoinKeeper.AddCoins(ctx, addr, coins)
+	require.Nil(t, err)
+}
+
+// fundModuleAccount adds coins to a module account without going through an account that holds them
+func fundModuleAccount(t *testing.T, ctx sdk.Context, k Keeper, moduleName string, coins sdk.Coins) {
+	fundAccount(t, ctx, k, k.SupplyKeeper.GetModuleAccount(ctx, moduleName).GetAddress(), coins)
+}
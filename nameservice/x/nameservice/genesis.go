@@ -4,54 +4,184 @@ import (
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice/internal/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
 type GenesisState struct {
-	WhoisRecords []Whois `json:"whois_records"`
+	WhoisRecords     []WhoisRecord     `json:"whois_records"`
+	Escrows          []Escrow          `json:"escrows"`
+	ChannelSequences []ChannelSequence `json:"channel_sequences"`
+	Auctions         []Auction         `json:"auctions"`
+	Params           Params            `json:"params"`
 }
 
-func NewGenesisState(whoIsRecords []Whois) GenesisState {
-	return GenesisState{WhoisRecords: nil}
+// WhoisRecord is the Whois of a name together with the name it is stored under
+type WhoisRecord struct {
+	Name  string `json:"name"`
+	Whois Whois  `json:"whois"`
+}
+
+// ChannelSequence is the number of channels a merchant has opened, needed to derive their next channel ID
+type ChannelSequence struct {
+	Merchant sdk.AccAddress `json:"merchant"`
+	Sequence uint64         `json:"sequence"`
+}
+
+func NewGenesisState(whoIsRecords []WhoisRecord, escrows []Escrow, channelSequences []ChannelSequence, auctions []Auction, params Params) GenesisState {
+	return GenesisState{
+		WhoisRecords:     whoIsRecords,
+		Escrows:          escrows,
+		ChannelSequences: channelSequences,
+		Auctions:         auctions,
+		Params:           params,
+	}
 }
 
 func ValidateGenesis(data GenesisState) error {
 	for _, record := range data.WhoisRecords {
-		if record.Owner == nil {
-			return fmt.Errorf("invalid WhoisRecord: Value: %s. Error: Missing Owner", record.Value)
+		if record.Name == "" {
+			return fmt.Errorf("invalid WhoisRecord: Value: %s. Error: Missing Name", record.Whois.Value)
+		}
+		if record.Whois.Owner == nil {
+			return fmt.Errorf("invalid WhoisRecord: Name: %s. Error: Missing Owner", record.Name)
 		}
-		if record.Value == "" {
-			return fmt.Errorf("invalid WhoisRecord: Owner: %s. Error: Missing Value", record.Owner)
+		if record.Whois.Value == "" {
+			return fmt.Errorf("invalid WhoisRecord: Name: %s. Error: Missing Value", record.Name)
 		}
-		if record.Price == nil {
-			return fmt.Errorf("invalid WhoisRecord: Value: %s. Error: Missing Price", record.Value)
+		if record.Whois.Price.Len() != 1 || !record.Whois.Price.IsAllPositive() {
+			return fmt.Errorf("invalid WhoisRecord: Name: %s. Error: Price must be a single positive denomination", record.Name)
+		}
+		if record.Whois.ExpiryHeight <= 0 {
+			return fmt.Errorf("invalid WhoisRecord: Name: %s. Error: ExpiryHeight must be positive", record.Name)
 		}
 	}
-	return nil
+
+	channelIDs := make(map[string]bool)
+	for _, escrow := range data.Escrows {
+		if escrow.ChannelID == "" {
+			return fmt.Errorf("invalid Escrow: Merchant: %s. Error: Missing ChannelID", escrow.Merchant)
+		}
+		if channelIDs[escrow.ChannelID] {
+			return fmt.Errorf("invalid Escrow: ChannelID: %s. Error: Duplicate ChannelID", escrow.ChannelID)
+		}
+		channelIDs[escrow.ChannelID] = true
+		if escrow.Merchant.Empty() {
+			return fmt.Errorf("invalid Escrow: ChannelID: %s. Error: Missing Merchant", escrow.ChannelID)
+		}
+		if escrow.Amount.Len() != 1 || !escrow.Amount.IsAllPositive() {
+			return fmt.Errorf("invalid Escrow: ChannelID: %s. Error: Amount must be a single positive denomination", escrow.ChannelID)
+		}
+		if escrow.Deposit.Len() != 1 || !escrow.Deposit.IsAllPositive() {
+			return fmt.Errorf("invalid Escrow: ChannelID: %s. Error: Deposit must be a single positive denomination", escrow.ChannelID)
+		}
+		if !escrow.Amount.DenomsSubsetOf(escrow.ExpectedAmount()) || !escrow.Amount.IsEqual(escrow.ExpectedAmount()) {
+			return fmt.Errorf("invalid Escrow: ChannelID: %s. Error: Amount must be the Deposit, doubled once filled", escrow.ChannelID)
+		}
+		if escrow.Filled == escrow.Customer.Empty() {
+			return fmt.Errorf("invalid Escrow: ChannelID: %s. Error: Customer must be set if and only if the escrow is filled", escrow.ChannelID)
+		}
+		if escrow.IsClosing() && !escrow.Filled {
+			return fmt.Errorf("invalid Escrow: ChannelID: %s. Error: Only a filled escrow can be closing", escrow.ChannelID)
+		}
+		if escrow.IsClosing() && (escrow.Close.Height <= 0 || escrow.Close.MatureHeight <= 0) {
+			return fmt.Errorf("invalid Escrow: ChannelID: %s. Error: Close heights must be positive", escrow.ChannelID)
+		}
+	}
+
+	for _, sequence := range data.ChannelSequences {
+		if sequence.Merchant.Empty() {
+			return fmt.Errorf("invalid ChannelSequence: Sequence: %d. Error: Missing Merchant", sequence.Sequence)
+		}
+	}
+
+	auctionNames := make(map[string]bool)
+	for _, auction := range data.Auctions {
+		if auction.Name == "" {
+			return fmt.Errorf("invalid Auction: RevealEndHeight: %d. Error: Missing Name", auction.RevealEndHeight)
+		}
+		if auctionNames[auction.Name] {
+			return fmt.Errorf("invalid Auction: Name: %s. Error: Duplicate Name", auction.Name)
+		}
+		auctionNames[auction.Name] = true
+		if auction.RevealEndHeight < auction.CommitEndHeight {
+			return fmt.Errorf("invalid Auction: Name: %s. Error: Reveal phase ends before the commit phase", auction.Name)
+		}
+		for _, bid := range auction.Bids {
+			if bid.Bidder.Empty() {
+				return fmt.Errorf("invalid Auction: Name: %s. Error: Bid missing Bidder", auction.Name)
+			}
+			if bid.Deposit.Len() != 1 || !bid.Deposit.IsAllPositive() {
+				return fmt.Errorf("invalid Auction: Name: %s. Error: Deposit of %s must be a single positive denomination", auction.Name, bid.Bidder)
+			}
+			if bid.Revealed && !bid.Amount.IsAllLTE(bid.Deposit) {
+				return fmt.Errorf("invalid Auction: Name: %s. Error: Bid of %s is not covered by its deposit", auction.Name, bid.Bidder)
+			}
+		}
+	}
+	return types.ValidateParams(data.Params)
 }
 
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
-		WhoisRecords: []Whois{},
+		WhoisRecords:     []WhoisRecord{},
+		Escrows:          []Escrow{},
+		ChannelSequences: []ChannelSequence{},
+		Auctions:         []Auction{},
+		Params:           types.DefaultParams(),
 	}
 }
 
 func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) []abci.ValidatorUpdate {
 	for _, record := range data.WhoisRecords {
-		keeper.SetWhois(ctx, record.Value, record)
+		keeper.SetWhois(ctx, record.Name, record.Whois)
+	}
+	for _, escrow := range data.Escrows {
+		keeper.SetEscrow(ctx, escrow.ChannelID, escrow)
+		if escrow.IsClosing() {
+			keeper.InsertClosingQueue(ctx, escrow.Close.MatureHeight, escrow.ChannelID)
+		}
+	}
+	for _, sequence := range data.ChannelSequences {
+		keeper.SetChannelSequence(ctx, sequence.Merchant, sequence.Sequence)
 	}
+	for _, auction := range data.Auctions {
+		keeper.SetAuction(ctx, auction)
+	}
+	keeper.SetParams(ctx, data.Params)
+	// A chain started from genesis is already on the current key schema
+	keeper.SetStoreVersion(ctx, types.StoreVersion)
 	return []abci.ValidatorUpdate{}
 }
 
 func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
-	var records []Whois
+	var records []WhoisRecord
 	iterator := k.GetNamesIterator(ctx)
+	defer iterator.Close()
 	for ; iterator.Valid(); iterator.Next() {
 
-		name := string(iterator.Key())
+		name := types.NameFromWhoisKey(iterator.Key())
 		whois := k.GetWhois(ctx, name)
-		records = append(records, whois)
+		records = append(records, WhoisRecord{Name: name, Whois: whois})
 
 	}
-	return GenesisState{WhoisRecords: records}
+
+	var escrows []Escrow
+	k.IterateEscrows(ctx, func(escrow Escrow) bool {
+		escrows = append(escrows, escrow)
+		return false
+	})
+
+	var sequences []ChannelSequence
+	k.IterateChannelSequences(ctx, func(merchant sdk.AccAddress, sequence uint64) bool {
+		sequences = append(sequences, ChannelSequence{Merchant: merchant, Sequence: sequence})
+		return false
+	})
+
+	var auctions []Auction
+	k.IterateAuctions(ctx, func(auction Auction) bool {
+		auctions = append(auctions, auction)
+		return false
+	})
+	return NewGenesisState(records, escrows, sequences, auctions, k.GetParams(ctx))
 }
@@ -0,0 +1,79 @@
+package nameservice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice/internal/types"
+)
+
+func TestExportImportMaturedClose(t *testing.T) {
+	ctx, k := createTestInput(t)
+	ctx = ctx.WithBlockHeight(20)
+
+	merchant := testAddr("merchant")
+	customer := testAddr("customer")
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10))
+	channelID := NewChannelID(merchant, []byte("token"), 0)
+
+	// A close that matured at height 10 but has not been paid out yet
+	balance := NewChannelBalance(channelID, 1, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 15)), sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5)))
+	pending := types.NewPendingClose(balance, customer, 5, ctx.BlockTime(), 5)
+	k.SetEscrow(ctx, channelID, Escrow{
+		ChannelID: channelID,
+		Merchant:  merchant,
+		Customer:  customer,
+		Amount:    deposit.Add(deposit),
+		Deposit:   deposit,
+		Filled:    true,
+		Close:     &pending,
+	})
+	k.InsertClosingQueue(ctx, pending.MatureHeight, channelID)
+
+	k.RebaseClosingQueue(ctx, ctx.BlockHeight())
+	genState := ExportGenesis(ctx, k)
+	require.NoError(t, ValidateGenesis(genState))
+	require.Len(t, genState.Escrows, 1)
+	require.Equal(t, int64(1), genState.Escrows[0].Close.Height)
+	require.Equal(t, int64(1), genState.Escrows[0].Close.MatureHeight)
+
+	// Import into a chain restarting at height zero, whose first block pays the close out once
+	newCtx, newK := createTestInput(t)
+	newCtx = newCtx.WithBlockHeight(0)
+	InitGenesis(newCtx, newK, genState)
+	fundModuleAccount(t, newCtx, newK, EscrowAccountName, deposit.Add(deposit))
+
+	EndBlocker(newCtx.WithBlockHeight(1), newK)
+	require.False(t, newK.IsEscrowPresent(newCtx, channelID))
+	require.Equal(t, balance.MerchantBalance, newK.CoinKeeper.GetCoins(newCtx, merchant))
+	require.Equal(t, balance.CustomerBalance, newK.CoinKeeper.GetCoins(newCtx, customer))
+
+	EndBlocker(newCtx.WithBlockHeight(2), newK)
+	require.Equal(t, balance.MerchantBalance, newK.CoinKeeper.GetCoins(newCtx, merchant))
+	require.Equal(t, balance.CustomerBalance, newK.CoinKeeper.GetCoins(newCtx, customer))
+	require.True(t, newK.SupplyKeeper.GetModuleAccount(newCtx, EscrowAccountName).GetCoins().IsZero())
+}
+
+func TestValidateGenesisRejectsUnrebasedClose(t *testing.T) {
+	merchant := testAddr("merchant")
+	customer := testAddr("customer")
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10))
+	channelID := NewChannelID(merchant, []byte("token"), 0)
+
+	balance := NewChannelBalance(channelID, 1, deposit, deposit)
+	pending := types.NewPendingClose(balance, customer, -5, time.Time{}, 5)
+	genState := DefaultGenesisState()
+	genState.Escrows = []Escrow{{
+		ChannelID: channelID,
+		Merchant:  merchant,
+		Customer:  customer,
+		Amount:    deposit.Add(deposit),
+		Deposit:   deposit,
+		Filled:    true,
+		Close:     &pending,
+	}}
+	require.Error(t, ValidateGenesis(genState))
+}
@@ -1,11 +1,14 @@
 package nameservice
 
 import (
+	"bytes"
 	"fmt"
+	"strconv"
 
 	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice/internal/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 )
 
 // NewHandler returns a handler for "nameservice" type messages.
@@ -18,10 +21,28 @@ func NewHandler(keeper Keeper) sdk.Handler {
 			return handleMsgBuyName(ctx, keeper, msg)
 		case MsgDeleteName:
 			return handleMsgDeleteName(ctx, keeper, msg)
+		case MsgRenewName:
+			return handleMsgRenewName(ctx, keeper, msg)
+		case MsgCommitBid:
+			return handleMsgCommitBid(ctx, keeper, msg)
+		case MsgRevealBid:
+			return handleMsgRevealBid(ctx, keeper, msg)
 		case MsgCreateOrder:
 			return handleMsgCreateOrder(ctx, keeper, msg)
 		case MsgFillOrder:
 			return handleMsgFillOrder(ctx, keeper, msg)
+		case MsgClaimOrder:
+			return handleMsgClaimOrder(ctx, keeper, msg)
+		case MsgBatchClaim:
+			return handleMsgBatchClaim(ctx, keeper, msg)
+		case MsgDisputeClose:
+			return handleMsgDisputeClose(ctx, keeper, msg)
+		case MsgCustomerClose:
+			return handleMsgCustomerClose(ctx, keeper, msg)
+		case MsgRevokeClose:
+			return handleMsgRevokeClose(ctx, keeper, msg)
+		case MsgMutualClose:
+			return handleMsgMutualClose(ctx, keeper, msg)
 		default:
 			errMsg := fmt.Sprintf("Unrecognized nameservice Msg type: %v", msg.Type())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -34,30 +55,91 @@ func handleMsgSetName(ctx sdk.Context, keeper Keeper, msg MsgSetName) sdk.Result
 	if !msg.Owner.Equals(keeper.GetOwner(ctx, msg.Name)) { // Checks if the the msg sender is the same as the current owner
 		return sdk.ErrUnauthorized("Incorrect Owner").Result() // If not, throw an error
 	}
+	if whois := keeper.GetWhois(ctx, msg.Name); whois.IsExpired(ctx.BlockHeight()) {
+		return types.ErrNameExpired(types.DefaultCodespace, whois.ExpiryHeight).Result()
+	}
 	keeper.SetName(ctx, msg.Name, msg.Value) // If so, set the name to the value specified in the msg.
-	return sdk.Result{}                      // return
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSetName,
+			sdk.NewAttribute(types.AttributeKeyName, msg.Name),
+			sdk.NewAttribute(types.AttributeKeyValue, msg.Value),
+			sdk.NewAttribute(types.AttributeKeyOwner, msg.Owner.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Owner.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
 }
 
 // Handle a message to buy name
 func handleMsgBuyName(ctx sdk.Context, keeper Keeper, msg MsgBuyName) sdk.Result {
+	// Names being auctioned, or every name in auction only mode, can only be bought with a sealed bid
+	if keeper.GetParams(ctx).AuctionOnly {
+		return types.ErrAuctionRequired(types.DefaultCodespace, "Names can only be bought by sealed-bid auction").Result()
+	}
+	if _, found := keeper.GetAuction(ctx, msg.Name); found {
+		return types.ErrAuctionRequired(types.DefaultCodespace, fmt.Sprintf("Name %s is being auctioned", msg.Name)).Result()
+	}
+	// An expired name is held for its owner to renew until the grace period ends and it is released
+	if whois := keeper.GetWhois(ctx, msg.Name); !whois.Owner.Empty() && whois.IsExpired(ctx.BlockHeight()) {
+		return types.ErrNameExpired(types.DefaultCodespace, whois.ExpiryHeight).Result()
+	}
+	// Names are priced in a single denomination, which the bid must be in so that the auctions can compare bids
+	price := keeper.GetPrice(ctx, msg.Name)
+	if price.Len() != 1 || msg.Bid[0].Denom != price[0].Denom {
+		return types.ErrDenomMismatch(types.DefaultCodespace, fmt.Sprintf("Bid must be in the denomination of the name price %s", price)).Result()
+	}
 	// Checks if the the bid price is greater than the price paid by the current owner
-	if keeper.GetPrice(ctx, msg.Name).IsAllGT(msg.Bid) {
+	if price.IsAllGT(msg.Bid) {
 		return sdk.ErrInsufficientCoins("Bid not high enough").Result() // If not, throw an error
 	}
-	if keeper.HasOwner(ctx, msg.Name) {
-		err := keeper.CoinKeeper.SendCoins(ctx, msg.Buyer, keeper.GetOwner(ctx, msg.Name), msg.Bid)
+	// The previous owner is paid the bid less the protocol fee, which goes to the fee collector.
+	// A name nobody owns pays the whole bid to the fee collector
+	previousOwner := keeper.GetOwner(ctx, msg.Name)
+	proceeds, protocolFee := keeper.SplitProceeds(ctx, previousOwner, msg.Bid)
+	if !proceeds.Empty() {
+		err := keeper.CoinKeeper.SendCoins(ctx, msg.Buyer, previousOwner, proceeds)
 		if err != nil {
 			return sdk.ErrInsufficientCoins("Buyer does not have enough coins").Result()
 		}
-	} else {
-		_, err := keeper.CoinKeeper.SubtractCoins(ctx, msg.Buyer, msg.Bid) // If so, deduct the Bid amount from the sender
+	}
+	if !protocolFee.Empty() {
+		err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Buyer, auth.FeeCollectorName, protocolFee)
 		if err != nil {
 			return sdk.ErrInsufficientCoins("Buyer does not have enough coins").Result()
 		}
 	}
+	// The buyer owns the name for a full lifetime from now
+	expiryHeight := ctx.BlockHeight() + keeper.GetParams(ctx).NameLifetime
 	keeper.SetOwner(ctx, msg.Name, msg.Buyer)
 	keeper.SetPrice(ctx, msg.Name, msg.Bid)
-	return sdk.Result{}
+	keeper.SetExpiryHeight(ctx, msg.Name, expiryHeight)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeBuyName,
+			sdk.NewAttribute(types.AttributeKeyName, msg.Name),
+			sdk.NewAttribute(types.AttributeKeyBuyer, msg.Buyer.String()),
+			sdk.NewAttribute(types.AttributeKeyPreviousOwner, previousOwner.String()),
+			sdk.NewAttribute(types.AttributeKeyPrice, msg.Bid.String()),
+			sdk.NewAttribute(types.AttributeKeyProceeds, proceeds.String()),
+			sdk.NewAttribute(types.AttributeKeyProtocolFee, protocolFee.String()),
+			sdk.NewAttribute(types.AttributeKeyExpiryHeight, strconv.FormatInt(expiryHeight, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Buyer.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
 }
 
 // Handle a message to delete name
@@ -70,89 +152,655 @@ func handleMsgDeleteName(ctx sdk.Context, keeper Keeper, msg MsgDeleteName) sdk.
 	}
 
 	keeper.DeleteWhois(ctx, msg.Name)
-	return sdk.Result{}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeDeleteName,
+			sdk.NewAttribute(types.AttributeKeyName, msg.Name),
+			sdk.NewAttribute(types.AttributeKeyOwner, msg.Owner.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Owner.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+// Handle a message to renew name
+func handleMsgRenewName(ctx sdk.Context, keeper Keeper, msg MsgRenewName) sdk.Result {
+	// 1. Check that the name exists and belongs to the Owner. A name past its grace period has already been released
+	// 2. Charge the Owner the renewal fee and extend the name by the name lifetime
+	if !keeper.IsNamePresent(ctx, msg.Name) {
+		return types.ErrNameDoesNotExist(types.DefaultCodespace).Result()
+	}
+	if !msg.Owner.Equals(keeper.GetOwner(ctx, msg.Name)) {
+		return sdk.ErrUnauthorized("Incorrect Owner").Result()
+	}
+
+	expiryHeight, err := keeper.RenewName(ctx, msg.Name, ctx.BlockHeight())
+	if err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeRenewName,
+			sdk.NewAttribute(types.AttributeKeyName, msg.Name),
+			sdk.NewAttribute(types.AttributeKeyOwner, msg.Owner.String()),
+			sdk.NewAttribute(types.AttributeKeyFee, keeper.GetParams(ctx).NameRenewalFee.String()),
+			sdk.NewAttribute(types.AttributeKeyExpiryHeight, strconv.FormatInt(expiryHeight, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Owner.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+// Handle a message to commit a sealed bid
+func handleMsgCommitBid(ctx sdk.Context, keeper Keeper, msg MsgCommitBid) sdk.Result {
+	// 1. Check that the name is not expired and held for its owner to renew during the grace period
+	// 2. Check that the deposit covers the price of the name in its denomination, so that the bid can win
+	// 3. Start an auction for the name if there is none, or check that the open one still takes bids
+	// 4. Move the deposit into the auction module account and add the sealed bid to the auction
+	whois := keeper.GetWhois(ctx, msg.Name)
+	if !whois.Owner.Empty() && whois.IsExpired(ctx.BlockHeight()) {
+		return types.ErrNameExpired(types.DefaultCodespace, whois.ExpiryHeight).Result()
+	}
+	price := whois.Price
+	if price.Len() != 1 || msg.Deposit[0].Denom != price[0].Denom {
+		return types.ErrDenomMismatch(types.DefaultCodespace, fmt.Sprintf("Deposit must be in the denomination of the name price %s", price)).Result()
+	}
+	if !msg.Deposit.IsAllGTE(price) {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf("Deposit must cover the name price %s", price)).Result()
+	}
+
+	auction, found := keeper.GetAuction(ctx, msg.Name)
+	if !found {
+		params := keeper.GetParams(ctx)
+		auction = types.NewAuction(msg.Name, ctx.BlockHeight(), params.AuctionCommitPeriod, params.AuctionRevealPeriod)
+	} else if !auction.IsCommitPhase(ctx.BlockHeight()) {
+		return types.ErrInvalidAuctionPhase(types.DefaultCodespace, fmt.Sprintf("Auction stopped taking bids at height %d", auction.CommitEndHeight)).Result()
+	}
+	if auction.BidIndex(msg.Bidder) >= 0 {
+		return types.ErrBidAlreadyCommitted(types.DefaultCodespace).Result()
+	}
+
+	bid := types.SealedBid{
+		Bidder:  msg.Bidder,
+		BidHash: msg.BidHash,
+		Deposit: msg.Deposit,
+	}
+	if err := keeper.CommitBid(ctx, auction, bid); err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeCommitBid,
+			sdk.NewAttribute(types.AttributeKeyName, msg.Name),
+			sdk.NewAttribute(types.AttributeKeyBidder, msg.Bidder.String()),
+			sdk.NewAttribute(types.AttributeKeyDeposit, msg.Deposit.String()),
+			sdk.NewAttribute(types.AttributeKeyCommitEndHeight, strconv.FormatInt(auction.CommitEndHeight, 10)),
+			sdk.NewAttribute(types.AttributeKeyRevealEndHeight, strconv.FormatInt(auction.RevealEndHeight, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Bidder.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+// Handle a message to reveal a sealed bid
+func handleMsgRevealBid(ctx sdk.Context, keeper Keeper, msg MsgRevealBid) sdk.Result {
+	// 1. Check that the auction is in its reveal phase and the Bidder has an unrevealed bid in it
+	// 2. Check that the bid matches the committed hash and is covered by the deposit
+	auction, found := keeper.GetAuction(ctx, msg.Name)
+	if !found {
+		return types.ErrAuctionNotFound(types.DefaultCodespace, msg.Name).Result()
+	}
+	if !auction.IsRevealPhase(ctx.BlockHeight()) {
+		return types.ErrInvalidAuctionPhase(types.DefaultCodespace, fmt.Sprintf("Bids can only be revealed from height %d to %d",
+			auction.CommitEndHeight+1, auction.RevealEndHeight)).Result()
+	}
+	i := auction.BidIndex(msg.Bidder)
+	if i < 0 {
+		return types.ErrBidNotFound(types.DefaultCodespace).Result()
+	}
+	bid := auction.Bids[i]
+	if bid.Revealed {
+		return types.ErrInvalidBidReveal(types.DefaultCodespace, "Bid has already been revealed").Result()
+	}
+	if !bytes.Equal(types.NewBidHash(msg.Name, msg.Bidder, msg.Amount, msg.Salt), bid.BidHash) {
+		return types.ErrInvalidBidReveal(types.DefaultCodespace, "Bid does not match the committed bid hash").Result()
+	}
+	if !msg.Amount.IsAllLTE(bid.Deposit) {
+		return types.ErrInvalidBidReveal(types.DefaultCodespace, fmt.Sprintf("Bid is not covered by the deposit %s", bid.Deposit)).Result()
+	}
+
+	auction.Bids[i].Revealed = true
+	auction.Bids[i].Amount = msg.Amount
+	keeper.SetAuction(ctx, auction)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeRevealBid,
+			sdk.NewAttribute(types.AttributeKeyName, msg.Name),
+			sdk.NewAttribute(types.AttributeKeyBidder, msg.Bidder.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, msg.Amount.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Bidder.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
 }
 
 // Handle a message to create an order
 func handleMsgCreateOrder(ctx sdk.Context, keeper Keeper, msg MsgCreateOrder) sdk.Result {
-	// 1. Check if the given BlsPubKey is valid
-	// 2. Check that the Merchant is only putting up a single denomination
-	// 3. Check if the Merchant already has an escrow account (should get deprecated)
-	// 4. Check if the Merchant has the necessary funds to escrow
-	// 5. Store Merchant funds in the KV
-	// var sigBytes Bls12381PubKey
-	// copy(sigBytes[:], msg.Owner)
-	// if !ValidatePubKey(sigBytes) {
-	// 	return sdk.ErrInvalidPubKey("Invalid BLS public key").Result()
-	// }
-
-	if msg.Amount.Len() != 1 {
-		return sdk.ErrInternal("Incorrect number of denominations. Must be 1").Result()
+	// 1. Check that the Merchant controls the ChannelState key
+	// 2. Check that the Merchant is putting up a single allowed denomination within the escrow bounds
+	// 3. Check if the Merchant has the necessary funds to escrow
+	// 4. Store Merchant funds in the KV under the next channel ID of the Merchant
+	// ValidateBasic only checked the length of the ChannelState, since deserializing it costs gas
+	var pubKey Bls12381PubKey
+	copy(pubKey[:], msg.ChannelState)
+	keeper.ConsumeBlsPubKeyGas(ctx, 1)
+	if !ValidatePubKey(pubKey) {
+		return types.ErrInvalidBlsPubKey(types.DefaultCodespace, "ChannelState is not a Bls12-381 public key").Result()
+	}
+	var proof Bls12381Signature
+	copy(proof[:], msg.ProofOfPossession)
+	keeper.ConsumeBlsVerifyGas(ctx, 1)
+	if !ValidateSignature(pubKey, proof, types.NewPossessionProof(msg.Merchant, ctx.ChainID()).GetSignBytes()) {
+		return types.ErrInvalidBlsSignature(types.DefaultCodespace, "Invalid proof of possession of the ChannelState key").Result()
 	}
 
-	if keeper.IsEscrowPresent(ctx, msg.Merchant.String()) {
-		return sdk.ErrInternal("Merchant already has one escrow. Currently only one is supported at a time.").Result()
+	if err := keeper.GetParams(ctx).ValidateEscrowAmount(msg.Amount); err != nil {
+		return err.Result()
 	}
 
-	coins, err := keeper.CoinKeeper.SubtractCoins(ctx, msg.Merchant, msg.Amount)
+	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Merchant, types.EscrowAccountName, msg.Amount)
 	if err != nil {
 		return sdk.ErrInsufficientCoins("Merchant does not have enough coins to escrow").Result()
 	}
 
-	keeper.SetEscrow(ctx, msg.Merchant.String(), Escrow{
+	channelID := keeper.NextChannelID(ctx, msg.Merchant, msg.ChannelToken)
+	keeper.SetEscrow(ctx, channelID, Escrow{
+		ChannelID:    channelID,
 		Merchant:     msg.Merchant,
 		ChannelState: msg.ChannelState,
 		ChannelToken: msg.ChannelToken,
-		Amount:       coins,
+		Amount:       msg.Amount,
+		Deposit:      msg.Amount,
 		Filled:       false,
 	})
 
-	return sdk.Result{}
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeCreateOrder,
+			sdk.NewAttribute(types.AttributeKeyChannelID, channelID),
+			sdk.NewAttribute(types.AttributeKeyMerchant, msg.Merchant.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, msg.Amount.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Merchant.String()),
+		),
+	})
+
+	return sdk.Result{Data: []byte(channelID), Events: ctx.EventManager().Events()}
 }
 
 // Handle a message to fill an order
 func handleMsgFillOrder(ctx sdk.Context, keeper Keeper, msg MsgFillOrder) sdk.Result {
-	// 1. Check if the given BlsPubKey is valid
-	// 2. Check if the escrow account exists
-	// 3. Check if the order has already been filled
-	// 4. Check that the amount and denomination put up by the Customer is correct
-	// 5. TODO Some stuff to store the correct keys in the escrow for claim
-	// 6. Store Customer funds in the KV
-	// var sigBytes Bls12381PubKey
-	// copy(sigBytes[:], msg.Owner)
-	// if !ValidatePubKey(sigBytes) {
-	// 	return sdk.ErrInvalidPubKey("Invalid BLS public key").Result()
-	// }
-
+	// 1. Check if the escrow account exists
+	// 2. Check if the order has already been filled
+	// 3. Check that the amount and denomination put up by the Customer is correct
+	// 4. Store Customer funds and the WalletCommit in the KV
 	if msg.Amount.Len() != 1 {
-		return sdk.ErrInternal("Incorrect number of denominations. Must be 1").Result()
+		return types.ErrDenomMismatch(types.DefaultCodespace, "Incorrect number of denominations. Must be 1").Result()
+	}
+
+	// ValidateBasic only checked the length of the WalletCommit, since deserializing it costs gas
+	var pubKey Bls12381PubKey
+	copy(pubKey[:], msg.WalletCommit)
+	keeper.ConsumeBlsPubKeyGas(ctx, 1)
+	if !ValidatePubKey(pubKey) {
+		return types.ErrInvalidBlsPubKey(types.DefaultCodespace, "WalletCommit is not a Bls12-381 public key").Result()
 	}
 
-	if !keeper.IsEscrowPresent(ctx, msg.Merchant.String()) {
-		return sdk.ErrInternal("Order does not exist. Merchant Escrow not found").Result()
+	if !keeper.IsEscrowPresent(ctx, msg.ChannelID) {
+		return types.ErrOrderNotFound(types.DefaultCodespace, msg.ChannelID).Result()
 	}
 
-	escrow := keeper.GetEscrow(ctx, msg.Merchant.String())
+	escrow := keeper.GetEscrow(ctx, msg.ChannelID)
 
 	if escrow.Filled {
-		return sdk.ErrInternal("Order has already been filled").Result()
+		return types.ErrOrderAlreadyFilled(types.DefaultCodespace).Result()
 	}
 
+	if !msg.Amount.DenomsSubsetOf(escrow.Amount) {
+		return types.ErrDenomMismatch(types.DefaultCodespace, fmt.Sprintf("Incorrect coins. Escrow has %s", escrow.Amount.String())).Result()
+	}
 	if !msg.Amount.IsEqual(escrow.Amount) {
-		if !msg.Amount[0].Amount.Equal(escrow.Amount[0].Amount) {
-			return sdk.ErrInvalidCoins(fmt.Sprintf("Incorrect amount. Should be %s", escrow.Amount[0].String())).Result()
-		}
-		return sdk.ErrInvalidCoins(fmt.Sprintf("Incorrect coins. Escrow has %s", escrow.Amount.String())).Result()
+		return types.ErrInvalidEscrowAmount(types.DefaultCodespace, fmt.Sprintf("Incorrect amount. Should be %s", escrow.Amount[0].String())).Result()
 	}
 
 	// ---- TODO MuliSig Shizen ----
 
-	coins, err := keeper.CoinKeeper.SubtractCoins(ctx, msg.Customer, msg.Amount)
+	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Customer, types.EscrowAccountName, msg.Amount)
 	if err != nil {
 		return sdk.ErrInsufficientCoins("Customer does not have enough coins to fill order").Result()
 	}
 
 	// This SetEscrow could be done using the already-queried-for-escrow for one less read/deserialize, but whatever
-	keeper.SetCustomer(ctx, msg.Merchant.String(), msg.Customer, msg.WalletCommit, coins)
-	return sdk.Result{}
+	keeper.SetCustomer(ctx, msg.ChannelID, msg.Customer, msg.WalletCommit, msg.Amount)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeFillOrder,
+			sdk.NewAttribute(types.AttributeKeyChannelID, msg.ChannelID),
+			sdk.NewAttribute(types.AttributeKeyMerchant, escrow.Merchant.String()),
+			sdk.NewAttribute(types.AttributeKeyCustomer, msg.Customer.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, msg.Amount.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Customer.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+// Handle a message to claim a filled order
+func handleMsgClaimOrder(ctx sdk.Context, keeper Keeper, msg MsgClaimOrder) sdk.Result {
+	// 1. Check if the escrow account exists and belongs to the Merchant
+	// 2. Check if the order has been filled and is not already closing
+	// 3. Check the balances and the Customer's signature over them against the WalletCommit key, so that the
+	//    Merchant can only close on a balance the Customer agreed to
+	// 4. Start the dispute period, after which the EndBlocker pays out the Merchant and Customer
+	if !keeper.IsEscrowPresent(ctx, msg.ChannelID) {
+		return types.ErrOrderNotFound(types.DefaultCodespace, msg.ChannelID).Result()
+	}
+
+	escrow := keeper.GetEscrow(ctx, msg.ChannelID)
+
+	if !msg.Merchant.Equals(escrow.Merchant) {
+		return sdk.ErrUnauthorized("Only the merchant who created the order can claim it").Result()
+	}
+
+	if !escrow.Filled {
+		return types.ErrOrderNotFilled(types.DefaultCodespace).Result()
+	}
+
+	if escrow.IsClosing() {
+		return types.ErrOrderClosing(types.DefaultCodespace).Result()
+	}
+
+	pubKey, err := escrow.WalletPubKey()
+	if err != nil {
+		return types.ErrInvalidBlsPubKey(types.DefaultCodespace, err.Error()).Result()
+	}
+
+	balance := msg.Balance()
+	if err := validateChannelBalance(ctx, keeper, escrow, balance, pubKey, msg.Signature); err != nil {
+		return err.Result()
+	}
+
+	pending := keeper.StartClose(ctx, msg.ChannelID, balance, msg.Merchant)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeClaim,
+			sdk.NewAttribute(types.AttributeKeyChannelID, msg.ChannelID),
+			sdk.NewAttribute(types.AttributeKeyNonce, strconv.FormatUint(balance.Nonce, 10)),
+			sdk.NewAttribute(types.AttributeKeyMerchantBalance, balance.MerchantBalance.String()),
+			sdk.NewAttribute(types.AttributeKeyCustomerBalance, balance.CustomerBalance.String()),
+			sdk.NewAttribute(types.AttributeKeyMatureHeight, strconv.FormatInt(pending.MatureHeight, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Merchant.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+// Handle a message to claim many filled orders with one aggregate signature
+func handleMsgBatchClaim(ctx sdk.Context, keeper Keeper, msg MsgBatchClaim) sdk.Result {
+	// 1. Check that every escrow account exists, belongs to the Merchant and is filled, rejecting the whole batch otherwise
+	// 2. Check the aggregate of the Customers' signatures over all balances against the WalletCommit keys, rejecting
+	//    the whole batch otherwise. Each balance names its own channel, so no two keys sign the same message
+	// 3. Check each order is not already closing and its balance adds up, rejecting only that claim otherwise
+	// 4. Start the dispute period of each accepted claim and report every claim's outcome
+	escrows := make([]Escrow, len(msg.Claims))
+	pubKeys := make([]Bls12381PubKey, len(msg.Claims))
+	signBytes := make([][]byte, len(msg.Claims))
+	for i, claim := range msg.Claims {
+		if !keeper.IsEscrowPresent(ctx, claim.ChannelID) {
+			return types.ErrOrderNotFound(types.DefaultCodespace, claim.ChannelID).Result()
+		}
+
+		escrows[i] = keeper.GetEscrow(ctx, claim.ChannelID)
+		if !msg.Merchant.Equals(escrows[i].Merchant) {
+			return sdk.ErrUnauthorized(fmt.Sprintf("Only the merchant who created order %s can claim it", claim.ChannelID)).Result()
+		}
+
+		if !escrows[i].Filled {
+			return types.ErrOrderNotFilled(types.DefaultCodespace).Result()
+		}
+
+		pubKey, err := escrows[i].WalletPubKey()
+		if err != nil {
+			return types.ErrInvalidBlsPubKey(types.DefaultCodespace, err.Error()).Result()
+		}
+		pubKeys[i] = pubKey
+		signBytes[i] = claim.Balance().GetSignBytes()
+	}
+
+	var signature Bls12381Signature
+	copy(signature[:], msg.Signature)
+	keeper.ConsumeBlsVerifyGas(ctx, len(pubKeys))
+	if !ValidateAggregateSignature(pubKeys, signature, signBytes) {
+		return types.ErrInvalidBlsSignature(types.DefaultCodespace, "Invalid aggregate customer signature over channel balances").Result()
+	}
+
+	results := make([]BatchClaimResult, len(msg.Claims))
+	for i, claim := range msg.Claims {
+		results[i] = BatchClaimResult{ChannelID: claim.ChannelID}
+
+		switch {
+		case escrows[i].IsClosing():
+			results[i].SetError(types.ErrOrderClosing(types.DefaultCodespace))
+		default:
+			balance := claim.Balance()
+			if err := validateBalanceTotal(escrows[i], balance); err != nil {
+				results[i].SetError(err)
+				continue
+			}
+			pending := keeper.StartClose(ctx, claim.ChannelID, balance, msg.Merchant)
+			results[i].Closing = true
+
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					types.EventTypeClaim,
+					sdk.NewAttribute(types.AttributeKeyChannelID, claim.ChannelID),
+					sdk.NewAttribute(types.AttributeKeyNonce, strconv.FormatUint(balance.Nonce, 10)),
+					sdk.NewAttribute(types.AttributeKeyMerchantBalance, balance.MerchantBalance.String()),
+					sdk.NewAttribute(types.AttributeKeyCustomerBalance, balance.CustomerBalance.String()),
+					sdk.NewAttribute(types.AttributeKeyMatureHeight, strconv.FormatInt(pending.MatureHeight, 10)),
+				),
+			)
+		}
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Merchant.String()),
+		),
+	)
+
+	return sdk.Result{Data: ModuleCdc.MustMarshalJSON(results), Events: ctx.EventManager().Events()}
+}
+
+// Handle a message to dispute a pending close with a newer balance
+func handleMsgDisputeClose(ctx sdk.Context, keeper Keeper, msg MsgDisputeClose) sdk.Result {
+	// 1. Check if the escrow account exists and is closing within its dispute period
+	// 2. Check that the Customer is the counterparty of the close
+	// 3. Check that the disputed balance is newer than the pending one
+	// 4. Check the balances and the Merchant's signature over them against the ChannelState key, so that the
+	//    Merchant is bound to having moved the channel past the balance it closed on
+	// 5. Punish the Merchant for closing on a stale balance by paying the whole escrow to the Customer
+	if !keeper.IsEscrowPresent(ctx, msg.ChannelID) {
+		return types.ErrOrderNotFound(types.DefaultCodespace, msg.ChannelID).Result()
+	}
+
+	escrow := keeper.GetEscrow(ctx, msg.ChannelID)
+
+	if !escrow.IsClosing() {
+		return types.ErrOrderNotClosing(types.DefaultCodespace).Result()
+	}
+
+	if ctx.BlockHeight() >= escrow.Close.MatureHeight {
+		return types.ErrDisputePeriodExpired(types.DefaultCodespace, escrow.Close.MatureHeight).Result()
+	}
+
+	if !msg.Customer.Equals(escrow.Customer) {
+		return sdk.ErrUnauthorized("Only the customer can dispute a close").Result()
+	}
+
+	if !escrow.Close.Initiator.Equals(escrow.Merchant) {
+		return sdk.ErrUnauthorized("Only a merchant close can be disputed with a newer balance").Result()
+	}
+
+	if msg.Nonce <= escrow.Close.Balance.Nonce {
+		return types.ErrStaleNonce(types.DefaultCodespace, escrow.Close.Balance.Nonce).Result()
+	}
+
+	pubKey, err := escrow.ChannelPubKey()
+	if err != nil {
+		return types.ErrInvalidBlsPubKey(types.DefaultCodespace, err.Error()).Result()
+	}
+
+	if err := validateChannelBalance(ctx, keeper, escrow, msg.Balance(), pubKey, msg.Signature); err != nil {
+		return err.Result()
+	}
+
+	penalty := NewChannelBalance(escrow.ChannelID, msg.Nonce, sdk.Coins{}, escrow.Amount)
+	if err := keeper.PayoutEscrow(ctx, msg.ChannelID, penalty); err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeDisputeClose,
+			sdk.NewAttribute(types.AttributeKeyChannelID, msg.ChannelID),
+			sdk.NewAttribute(types.AttributeKeyNonce, strconv.FormatUint(msg.Nonce, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Customer.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+// Handle a message to close a filled order from the customer side
+func handleMsgCustomerClose(ctx sdk.Context, keeper Keeper, msg MsgCustomerClose) sdk.Result {
+	// 1. Check if the escrow account exists
+	// 2. Check that the Customer filled the order and it is not already closing
+	// 3. Check the balances and the Merchant's signature over them against the ChannelState key
+	// 4. Start the dispute period, during which the Merchant can reveal a revocation token for this wallet state
+	if !keeper.IsEscrowPresent(ctx, msg.ChannelID) {
+		return types.ErrOrderNotFound(types.DefaultCodespace, msg.ChannelID).Result()
+	}
+
+	escrow := keeper.GetEscrow(ctx, msg.ChannelID)
+
+	if !escrow.Filled || !msg.Customer.Equals(escrow.Customer) {
+		return sdk.ErrUnauthorized("Only the customer who filled the order can close it").Result()
+	}
+
+	if escrow.IsClosing() {
+		return types.ErrOrderClosing(types.DefaultCodespace).Result()
+	}
+
+	pubKey, err := escrow.ChannelPubKey()
+	if err != nil {
+		return types.ErrInvalidBlsPubKey(types.DefaultCodespace, err.Error()).Result()
+	}
+
+	balance := msg.Balance()
+	if err := validateChannelBalance(ctx, keeper, escrow, balance, pubKey, msg.Signature); err != nil {
+		return err.Result()
+	}
+
+	pending := keeper.StartClose(ctx, msg.ChannelID, balance, msg.Customer)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeCustomerClose,
+			sdk.NewAttribute(types.AttributeKeyChannelID, msg.ChannelID),
+			sdk.NewAttribute(types.AttributeKeyNonce, strconv.FormatUint(balance.Nonce, 10)),
+			sdk.NewAttribute(types.AttributeKeyMerchantBalance, balance.MerchantBalance.String()),
+			sdk.NewAttribute(types.AttributeKeyCustomerBalance, balance.CustomerBalance.String()),
+			sdk.NewAttribute(types.AttributeKeyMatureHeight, strconv.FormatInt(pending.MatureHeight, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Customer.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+// Handle a message to punish a customer close on a revoked wallet state
+func handleMsgRevokeClose(ctx sdk.Context, keeper Keeper, msg MsgRevokeClose) sdk.Result {
+	// 1. Check if the escrow account exists, belongs to the Merchant and the Customer is closing it within its dispute period
+	// 2. Check the revocation token for the closing nonce against the WalletCommit key
+	// 3. Punish the Customer by paying the whole escrow to the Merchant
+	if !keeper.IsEscrowPresent(ctx, msg.ChannelID) {
+		return types.ErrOrderNotFound(types.DefaultCodespace, msg.ChannelID).Result()
+	}
+
+	escrow := keeper.GetEscrow(ctx, msg.ChannelID)
+
+	if !msg.Merchant.Equals(escrow.Merchant) {
+		return sdk.ErrUnauthorized("Only the merchant who created the order can revoke a close").Result()
+	}
+
+	if !escrow.IsClosing() {
+		return types.ErrOrderNotClosing(types.DefaultCodespace).Result()
+	}
+
+	if ctx.BlockHeight() >= escrow.Close.MatureHeight {
+		return types.ErrDisputePeriodExpired(types.DefaultCodespace, escrow.Close.MatureHeight).Result()
+	}
+
+	if !escrow.Close.Initiator.Equals(escrow.Customer) {
+		return sdk.ErrUnauthorized("Only a customer close can be revoked").Result()
+	}
+
+	pubKey, err := escrow.WalletPubKey()
+	if err != nil {
+		return types.ErrInvalidBlsPubKey(types.DefaultCodespace, err.Error()).Result()
+	}
+
+	revocation := NewRevocation(escrow.ChannelID, escrow.Close.Balance.Nonce)
+	var token Bls12381Signature
+	copy(token[:], msg.RevocationToken)
+	keeper.ConsumeBlsVerifyGas(ctx, 1)
+	if !ValidateSignature(pubKey, token, revocation.GetSignBytes()) {
+		return types.ErrInvalidBlsSignature(types.DefaultCodespace, "Invalid revocation token").Result()
+	}
+
+	penalty := NewChannelBalance(escrow.ChannelID, escrow.Close.Balance.Nonce, escrow.Amount, sdk.Coins{})
+	if err := keeper.PayoutEscrow(ctx, msg.ChannelID, penalty); err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeRevokeClose,
+			sdk.NewAttribute(types.AttributeKeyChannelID, msg.ChannelID),
+			sdk.NewAttribute(types.AttributeKeyNonce, strconv.FormatUint(penalty.Nonce, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Merchant.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+// Handle a message to close a filled order cooperatively
+func handleMsgMutualClose(ctx sdk.Context, keeper Keeper, msg MsgMutualClose) sdk.Result {
+	// 1. Check if the escrow account exists
+	// 2. Check that the signers are the Merchant and Customer of the filled order
+	// 3. Check that the balances add up to the escrowed amount
+	// 4. Pay out the Merchant and Customer right away, overriding any pending close
+	if !keeper.IsEscrowPresent(ctx, msg.ChannelID) {
+		return types.ErrOrderNotFound(types.DefaultCodespace, msg.ChannelID).Result()
+	}
+
+	escrow := keeper.GetEscrow(ctx, msg.ChannelID)
+
+	if !escrow.Filled || !msg.Merchant.Equals(escrow.Merchant) || !msg.Customer.Equals(escrow.Customer) {
+		return sdk.ErrUnauthorized("Only the merchant and customer of a filled order can close it together").Result()
+	}
+
+	balance := msg.Balance()
+	if err := validateBalanceTotal(escrow, balance); err != nil {
+		return err.Result()
+	}
+
+	if err := keeper.PayoutEscrow(ctx, msg.ChannelID, balance); err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeMutualClose,
+			sdk.NewAttribute(types.AttributeKeyChannelID, msg.ChannelID),
+			sdk.NewAttribute(types.AttributeKeyMerchantBalance, balance.MerchantBalance.String()),
+			sdk.NewAttribute(types.AttributeKeyCustomerBalance, balance.CustomerBalance.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Merchant.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+// validateBalanceTotal checks that a balance adds up to the escrowed amount
+func validateBalanceTotal(escrow Escrow, balance ChannelBalance) sdk.Error {
+	total := balance.Total()
+	if !total.DenomsSubsetOf(escrow.Amount) || !total.IsEqual(escrow.Amount) {
+		return types.ErrBalanceMismatch(types.DefaultCodespace, escrow.Amount)
+	}
+	return nil
+}
+
+// validateChannelBalance checks that a balance adds up to the escrowed amount and is signed by pubKey
+func validateChannelBalance(ctx sdk.Context, keeper Keeper, escrow Escrow, balance ChannelBalance, pubKey Bls12381PubKey, sig []byte) sdk.Error {
+	if err := validateBalanceTotal(escrow, balance); err != nil {
+		return err
+	}
+
+	var signature Bls12381Signature
+	copy(signature[:], sig)
+	keeper.ConsumeBlsVerifyGas(ctx, 1)
+	if !ValidateSignature(pubKey, signature, balance.GetSignBytes()) {
+		return types.ErrInvalidBlsSignature(types.DefaultCodespace, "Invalid signature over channel balance")
+	}
+	return nil
 }
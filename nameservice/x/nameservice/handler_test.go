@@ -0,0 +1,278 @@
+package nameservice
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/phoreproject/bls/g2pubs"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice/internal/types"
+)
+
+func TestHandleMsgBuyNameDenom(t *testing.T) {
+	ctx, k := createTestInput(t)
+	handler := NewHandler(k)
+
+	buyer := testAddr("buyer")
+	fundAccount(t, ctx, k, buyer, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10), sdk.NewInt64Coin("stake", 10)))
+
+	// Names are priced in the denomination of the min name price
+	res := handler(ctx, NewMsgBuyName("name", sdk.NewCoins(sdk.NewInt64Coin("stake", 5)), buyer))
+	require.False(t, res.IsOK())
+	require.Equal(t, types.CodeDenomMismatch, res.Code)
+	require.False(t, k.HasOwner(ctx, "name"))
+
+	res = handler(ctx, NewMsgBuyName("name", sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5)), buyer))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, buyer, k.GetOwner(ctx, "name"))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5)), k.GetPrice(ctx, "name"))
+}
+
+func stake(amount int64) sdk.Coins {
+	return sdk.NewCoins(sdk.NewInt64Coin("stake", amount))
+}
+
+// openTestOrder has merchant create an order escrowing amount stake, returning its channel ID and the secret key
+// behind its ChannelState
+func openTestOrder(t *testing.T, ctx sdk.Context, k Keeper, merchant sdk.AccAddress, amount int64) (string, *g2pubs.SecretKey) {
+	fundAccount(t, ctx, k, merchant, stake(amount))
+	channelKey := g2pubs.DeriveSecretKey(sha256.Sum256(append([]byte("channel"), merchant...)))
+	channelState := g2pubs.PrivToPub(channelKey).Serialize()
+	proof := g2pubs.Sign(NewPossessionProof(merchant, ctx.ChainID()).GetSignBytes(), channelKey).Serialize()
+	res := NewHandler(k)(ctx, NewMsgCreateOrder(merchant, channelState[:], []byte("token"), proof[:], stake(amount)))
+	require.True(t, res.IsOK(), res.Log)
+	return string(res.Data), channelKey
+}
+
+// createTestOrder has merchant create an order escrowing amount stake and customer fill it, returning its channel ID
+// and the secret keys behind its ChannelState and WalletCommit
+func createTestOrder(t *testing.T, ctx sdk.Context, k Keeper, merchant, customer sdk.AccAddress, amount int64) (string, *g2pubs.SecretKey, *g2pubs.SecretKey) {
+	channelID, channelKey := openTestOrder(t, ctx, k, merchant, amount)
+	fundAccount(t, ctx, k, customer, stake(amount))
+
+	walletKey := g2pubs.DeriveSecretKey(sha256.Sum256(append([]byte("wallet"), customer...)))
+	walletCommit := g2pubs.PrivToPub(walletKey).Serialize()
+	res := NewHandler(k)(ctx, NewMsgFillOrder(channelID, customer, walletCommit[:], stake(amount)))
+	require.True(t, res.IsOK(), res.Log)
+	return channelID, channelKey, walletKey
+}
+
+// signTestBalance signs a channel balance with secretKey
+func signTestBalance(balance ChannelBalance, secretKey *g2pubs.SecretKey) []byte {
+	signature := g2pubs.Sign(balance.GetSignBytes(), secretKey).Serialize()
+	return signature[:]
+}
+
+func TestHandleMsgDisputeClose(t *testing.T) {
+	ctx, k := createTestInput(t)
+	handler := NewHandler(k)
+	merchant, customer := testAddr("merchant"), testAddr("customer")
+	channelID, channelKey, walletKey := createTestOrder(t, ctx, k, merchant, customer, 10)
+
+	// The merchant claims on the first balance the customer signed, starting a dispute period of 100 blocks
+	claimed := NewChannelBalance(channelID, 1, stake(15), stake(5))
+	res := handler(ctx, NewMsgClaimOrder(merchant, channelID, 1, stake(15), stake(5), signTestBalance(claimed, walletKey)))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, int64(101), k.GetEscrow(ctx, channelID).Close.MatureHeight)
+
+	newer := NewChannelBalance(channelID, 2, stake(12), stake(8))
+	dispute := NewMsgDisputeClose(customer, channelID, 2, stake(12), stake(8), signTestBalance(newer, channelKey))
+
+	// A newer balance can no longer be submitted once the dispute period has ended
+	res = handler(ctx.WithBlockHeight(101), dispute)
+	require.Equal(t, types.CodeDisputePeriodExpired, res.Code)
+
+	// Within the window the dispute needs a newer balance signed with the ChannelState key
+	stale := NewMsgDisputeClose(customer, channelID, 1, stake(12), stake(8), signTestBalance(NewChannelBalance(channelID, 1, stake(12), stake(8)), channelKey))
+	res = handler(ctx.WithBlockHeight(100), stale)
+	require.Equal(t, types.CodeStaleNonce, res.Code)
+	res = handler(ctx.WithBlockHeight(100), NewMsgDisputeClose(customer, channelID, 2, stake(12), stake(8), signTestBalance(newer, walletKey)))
+	require.Equal(t, types.CodeInvalidBlsSignature, res.Code)
+	require.True(t, k.GetEscrow(ctx, channelID).IsClosing())
+
+	// A valid dispute pays the whole escrow to the customer right away
+	res = handler(ctx.WithBlockHeight(100), dispute)
+	require.True(t, res.IsOK(), res.Log)
+	require.False(t, k.IsEscrowPresent(ctx, channelID))
+	require.Equal(t, stake(20), k.CoinKeeper.GetCoins(ctx, customer))
+	require.True(t, k.CoinKeeper.GetCoins(ctx, merchant).IsZero())
+
+	// Nothing is left in the closing queue to be paid out again
+	EndBlocker(ctx.WithBlockHeight(101), k)
+	require.Equal(t, stake(20), k.CoinKeeper.GetCoins(ctx, customer))
+	require.True(t, k.SupplyKeeper.GetModuleAccount(ctx, EscrowAccountName).GetCoins().IsZero())
+}
+
+func TestHandleMsgCustomerCloseRejected(t *testing.T) {
+	ctx, k := createTestInput(t)
+	handler := NewHandler(k)
+	merchant, customer := testAddr("merchant"), testAddr("customer")
+	channelID, channelKey, walletKey := createTestOrder(t, ctx, k, merchant, customer, 10)
+	escrow := k.GetEscrow(ctx, channelID)
+
+	// A close signed with the customer's own key or not adding up to the escrow is rejected
+	balance := NewChannelBalance(channelID, 1, stake(5), stake(15))
+	res := handler(ctx, NewMsgCustomerClose(customer, channelID, 1, stake(5), stake(15), signTestBalance(balance, walletKey)))
+	require.Equal(t, types.CodeInvalidBlsSignature, res.Code)
+	short := NewChannelBalance(channelID, 1, stake(5), stake(5))
+	res = handler(ctx, NewMsgCustomerClose(customer, channelID, 1, stake(5), stake(5), signTestBalance(short, channelKey)))
+	require.Equal(t, types.CodeBalanceMismatch, res.Code)
+
+	// and leaves the escrow open with nothing queued to be paid out
+	require.Equal(t, escrow, k.GetEscrow(ctx, channelID))
+	iterator := k.ClosingQueueIterator(ctx, 1000)
+	require.False(t, iterator.Valid())
+	iterator.Close()
+	EndBlocker(ctx.WithBlockHeight(1000), k)
+	require.Equal(t, stake(20), k.SupplyKeeper.GetModuleAccount(ctx, EscrowAccountName).GetCoins())
+}
+
+func TestHandleMsgRevokeClose(t *testing.T) {
+	ctx, k := createTestInput(t)
+	handler := NewHandler(k)
+	merchant, customer := testAddr("merchant"), testAddr("customer")
+	channelID, channelKey, walletKey := createTestOrder(t, ctx, k, merchant, customer, 10)
+
+	// The customer closes on a wallet state they have since revoked
+	balance := NewChannelBalance(channelID, 1, stake(5), stake(15))
+	res := handler(ctx, NewMsgCustomerClose(customer, channelID, 1, stake(5), stake(15), signTestBalance(balance, channelKey)))
+	require.True(t, res.IsOK(), res.Log)
+
+	// A token for another nonce, or not signed with the WalletCommit key, does not revoke the close
+	wrongNonce := g2pubs.Sign(NewRevocation(channelID, 2).GetSignBytes(), walletKey).Serialize()
+	res = handler(ctx.WithBlockHeight(50), NewMsgRevokeClose(merchant, channelID, wrongNonce[:]))
+	require.Equal(t, types.CodeInvalidBlsSignature, res.Code)
+	wrongKey := g2pubs.Sign(NewRevocation(channelID, 1).GetSignBytes(), channelKey).Serialize()
+	res = handler(ctx.WithBlockHeight(50), NewMsgRevokeClose(merchant, channelID, wrongKey[:]))
+	require.Equal(t, types.CodeInvalidBlsSignature, res.Code)
+	require.True(t, k.GetEscrow(ctx, channelID).IsClosing())
+	require.True(t, k.CoinKeeper.GetCoins(ctx, merchant).IsZero())
+
+	// The revocation token for the closing nonce pays the whole escrow to the merchant
+	token := g2pubs.Sign(NewRevocation(channelID, 1).GetSignBytes(), walletKey).Serialize()
+	res = handler(ctx.WithBlockHeight(50), NewMsgRevokeClose(merchant, channelID, token[:]))
+	require.True(t, res.IsOK(), res.Log)
+	require.False(t, k.IsEscrowPresent(ctx, channelID))
+	require.Equal(t, stake(20), k.CoinKeeper.GetCoins(ctx, merchant))
+	require.True(t, k.CoinKeeper.GetCoins(ctx, customer).IsZero())
+}
+
+func TestHandleMsgMutualClose(t *testing.T) {
+	ctx, k := createTestInput(t)
+	handler := NewHandler(k)
+	merchant, customer := testAddr("merchant"), testAddr("customer")
+	channelID, _, walletKey := createTestOrder(t, ctx, k, merchant, customer, 10)
+
+	// A pending merchant claim is overridden by the balance both parties agree on
+	claimed := NewChannelBalance(channelID, 1, stake(15), stake(5))
+	res := handler(ctx, NewMsgClaimOrder(merchant, channelID, 1, stake(15), stake(5), signTestBalance(claimed, walletKey)))
+	require.True(t, res.IsOK(), res.Log)
+
+	res = handler(ctx, NewMsgMutualClose(merchant, testAddr("other"), channelID, stake(12), stake(8)))
+	require.Equal(t, sdk.CodeUnauthorized, res.Code)
+	res = handler(ctx, NewMsgMutualClose(merchant, customer, channelID, stake(12), stake(7)))
+	require.Equal(t, types.CodeBalanceMismatch, res.Code)
+	require.True(t, k.GetEscrow(ctx, channelID).IsClosing())
+
+	// The agreed balance is paid out in the same block, without waiting for a dispute period
+	res = handler(ctx.WithBlockHeight(2), NewMsgMutualClose(merchant, customer, channelID, stake(12), stake(8)))
+	require.True(t, res.IsOK(), res.Log)
+	require.False(t, k.IsEscrowPresent(ctx, channelID))
+	require.Equal(t, stake(12), k.CoinKeeper.GetCoins(ctx, merchant))
+	require.Equal(t, stake(8), k.CoinKeeper.GetCoins(ctx, customer))
+
+	// and the overridden claim is no longer queued to be paid out
+	iterator := k.ClosingQueueIterator(ctx, 1000)
+	require.False(t, iterator.Valid())
+	iterator.Close()
+	EndBlocker(ctx.WithBlockHeight(101), k)
+	require.Equal(t, stake(12), k.CoinKeeper.GetCoins(ctx, merchant))
+	require.True(t, k.SupplyKeeper.GetModuleAccount(ctx, EscrowAccountName).GetCoins().IsZero())
+}
+
+// batchClaimGas returns the gas a batch claim consumes, without keeping its changes
+func batchClaimGas(t *testing.T, ctx sdk.Context, k Keeper, msg MsgBatchClaim) uint64 {
+	cacheCtx, _ := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	res := NewHandler(k)(cacheCtx, msg)
+	require.True(t, res.IsOK(), res.Log)
+	return cacheCtx.GasMeter().GasConsumed()
+}
+
+func TestHandleMsgBatchClaim(t *testing.T) {
+	ctx, k := createTestInput(t)
+	handler := NewHandler(k)
+	merchant := testAddr("merchant")
+
+	var claims []BatchClaim
+	var signatures []*g2pubs.Signature
+	for i, customer := range []string{"closing", "valid", "mismatch"} {
+		channelID, _, walletKey := createTestOrder(t, ctx, k, merchant, testAddr(customer), 10)
+		claim := NewBatchClaim(channelID, 1, stake(15), stake(5))
+		if customer == "mismatch" {
+			claim = NewBatchClaim(channelID, 1, stake(15), stake(15))
+		}
+		claims = append(claims, claim)
+		signatures = append(signatures, g2pubs.Sign(claim.Balance().GetSignBytes(), walletKey))
+
+		if i == 0 {
+			res := handler(ctx, NewMsgClaimOrder(merchant, channelID, 1, stake(15), stake(5), signTestBalance(claim.Balance(), walletKey)))
+			require.True(t, res.IsOK(), res.Log)
+		}
+	}
+	signature := g2pubs.AggregateSignatures(signatures).Serialize()
+	msg := NewMsgBatchClaim(merchant, claims, signature[:])
+
+	// Verification is charged for every claim in the batch, plus one pairing for the aggregate signature
+	singleSignature := signatures[1].Serialize()
+	single := NewMsgBatchClaim(merchant, claims[1:2], singleSignature[:])
+	params := DefaultParams()
+	batchGas, singleGas := batchClaimGas(t, ctx, k, msg), batchClaimGas(t, ctx, k, single)
+	params.BlsPubKeyGas += 1000
+	params.BlsPairingGas += 10000
+	k.SetParams(ctx, params)
+	require.Equal(t, 3*uint64(1000)+4*uint64(10000), batchClaimGas(t, ctx, k, msg)-batchGas)
+	require.Equal(t, uint64(1000)+2*uint64(10000), batchClaimGas(t, ctx, k, single)-singleGas)
+
+	// Each claim is settled or rejected on its own
+	res := handler(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+	var results []BatchClaimResult
+	ModuleCdc.MustUnmarshalJSON(res.Data, &results)
+	require.Len(t, results, 3)
+	require.False(t, results[0].Closing)
+	require.Equal(t, types.CodeOrderClosing, results[0].Code)
+	require.True(t, results[1].Closing)
+	require.Equal(t, sdk.CodeType(0), results[1].Code)
+	require.False(t, results[2].Closing)
+	require.Equal(t, types.CodeBalanceMismatch, results[2].Code)
+
+	// The rejected claims do not undo the accepted one
+	require.Equal(t, int64(1), k.GetEscrow(ctx, claims[0].ChannelID).Close.Height)
+	require.Equal(t, claims[1].Balance(), k.GetEscrow(ctx, claims[1].ChannelID).Close.Balance)
+	require.False(t, k.GetEscrow(ctx, claims[2].ChannelID).IsClosing())
+}
+
+func TestHandleOrderInvalidBlsPubKey(t *testing.T) {
+	ctx, k := createTestInput(t)
+	handler := NewHandler(k)
+	merchant, customer := testAddr("merchant"), testAddr("customer")
+	fundAccount(t, ctx, k, merchant, stake(10))
+	proof := make([]byte, 48)
+
+	// A key that is not on the curve is rejected by the handler, after charging for deserializing it
+	gasCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	res := handler(gasCtx, NewMsgCreateOrder(merchant, make([]byte, 96), []byte("token"), proof, stake(10)))
+	require.Equal(t, types.CodeInvalidBlsPubKey, res.Code)
+	require.True(t, gasCtx.GasMeter().GasConsumed() >= DefaultParams().BlsPubKeyGas)
+	require.Equal(t, stake(10), k.CoinKeeper.GetCoins(ctx, merchant))
+
+	channelID, _ := openTestOrder(t, ctx, k, merchant, 10)
+	fundAccount(t, ctx, k, customer, stake(10))
+	res = handler(ctx, NewMsgFillOrder(channelID, customer, make([]byte, 96), stake(10)))
+	require.Equal(t, types.CodeInvalidBlsPubKey, res.Code)
+	require.False(t, k.GetEscrow(ctx, channelID).Filled)
+	require.Equal(t, stake(10), k.CoinKeeper.GetCoins(ctx, customer))
+}
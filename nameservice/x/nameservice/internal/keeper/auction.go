@@ -0,0 +1,185 @@
+package keeper
+
+import (
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice/internal/types"
+)
+
+// GetAuction returns the open auction for a name, if there is one
+func (k Keeper) GetAuction(ctx sdk.Context, name string) (types.Auction, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.AuctionKey(name))
+	if bz == nil {
+		return types.Auction{}, false
+	}
+	var auction types.Auction
+	k.cdc.MustUnmarshalBinaryBare(bz, &auction)
+	return auction, true
+}
+
+// SetAuction sets the auction for a name and queues it to be settled when its reveal phase ends
+func (k Keeper) SetAuction(ctx sdk.Context, auction types.Auction) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.AuctionKey(auction.Name), k.cdc.MustMarshalBinaryBare(auction))
+	store.Set(types.AuctionQueueKey(auction.RevealEndHeight, auction.Name), []byte(auction.Name))
+}
+
+// DeleteAuction removes the auction for a name and its auction queue entry
+func (k Keeper) DeleteAuction(ctx sdk.Context, name string) {
+	auction, found := k.GetAuction(ctx, name)
+	if !found {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.AuctionQueueKey(auction.RevealEndHeight, name))
+	store.Delete(types.AuctionKey(name))
+}
+
+// IterateAuctions calls cb with every open auction until cb returns true
+func (k Keeper) IterateAuctions(ctx sdk.Context, cb func(auction types.Auction) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	itr := sdk.KVStorePrefixIterator(store, types.AuctionPrefix)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		var auction types.Auction
+		k.cdc.MustUnmarshalBinaryBare(itr.Value(), &auction)
+		if cb(auction) {
+			break
+		}
+	}
+}
+
+// AuctionQueueIterator returns an iterator over the auction queue up to and including height.
+// The values are the names whose auction reveal phase has ended
+func (k Keeper) AuctionQueueIterator(ctx sdk.Context, height int64) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return store.Iterator(types.AuctionQueuePrefix, types.AuctionQueueHeightKey(height+1))
+}
+
+// CommitBid moves a bidder's deposit into the auction module account and adds their sealed bid to the auction
+func (k Keeper) CommitBid(ctx sdk.Context, auction types.Auction, bid types.SealedBid) sdk.Error {
+	err := k.SupplyKeeper.SendCoinsFromAccountToModule(ctx, bid.Bidder, types.AuctionAccountName, bid.Deposit)
+	if err != nil {
+		return sdk.ErrInsufficientCoins("Bidder does not have enough coins to cover the deposit")
+	}
+	auction.Bids = append(auction.Bids, bid)
+	k.SetAuction(ctx, auction)
+	return nil
+}
+
+// SettleAuction ends the auction for a name. The highest revealed bid of at least the name's price wins the name
+// for a full lifetime and pays the second highest bid out of its deposit, split between the previous owner and the
+// fee collector by SplitProceeds. Every other revealed bid is refunded and every unrevealed deposit is burned
+func (k Keeper) SettleAuction(ctx sdk.Context, name string) sdk.Error {
+	auction, found := k.GetAuction(ctx, name)
+	if !found {
+		return types.ErrAuctionNotFound(types.DefaultCodespace, name)
+	}
+	whois := k.GetWhois(ctx, name)
+	winner, price, won := auction.Winner(whois.Price)
+
+	slashed := sdk.NewCoins()
+	for i, bid := range auction.Bids {
+		refund := bid.Deposit
+		switch {
+		case won && i == winner:
+			refund = bid.Deposit.Sub(price)
+		case !bid.Revealed:
+			slashed = slashed.Add(bid.Deposit)
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					types.EventTypeSlashDeposit,
+					sdk.NewAttribute(types.AttributeKeyName, name),
+					sdk.NewAttribute(types.AttributeKeyBidder, bid.Bidder.String()),
+					sdk.NewAttribute(types.AttributeKeyDeposit, bid.Deposit.String()),
+				),
+			)
+			continue
+		}
+		if refund.Empty() {
+			continue
+		}
+		err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, types.AuctionAccountName, bid.Bidder, refund)
+		if err != nil {
+			return err
+		}
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeRefundDeposit,
+				sdk.NewAttribute(types.AttributeKeyName, name),
+				sdk.NewAttribute(types.AttributeKeyBidder, bid.Bidder.String()),
+				sdk.NewAttribute(types.AttributeKeyAmount, refund.String()),
+			),
+		)
+	}
+	if !slashed.Empty() {
+		if err := k.SupplyKeeper.BurnCoins(ctx, types.AuctionAccountName, slashed); err != nil {
+			return err
+		}
+	}
+
+	k.DeleteAuction(ctx, name)
+	if !won {
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeSettleAuction,
+				sdk.NewAttribute(types.AttributeKeyName, name),
+			),
+		)
+		return nil
+	}
+
+	previousOwner := whois.Owner
+	proceeds, protocolFee := k.SplitProceeds(ctx, previousOwner, price)
+	if !proceeds.Empty() {
+		err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, types.AuctionAccountName, previousOwner, proceeds)
+		if err != nil {
+			return err
+		}
+	}
+	if !protocolFee.Empty() {
+		err := k.SupplyKeeper.SendCoinsFromModuleToModule(ctx, types.AuctionAccountName, auth.FeeCollectorName, protocolFee)
+		if err != nil {
+			return err
+		}
+	}
+
+	whois.Owner = auction.Bids[winner].Bidder
+	whois.Price = price
+	whois.ExpiryHeight = ctx.BlockHeight() + k.GetParams(ctx).NameLifetime
+	k.SetWhois(ctx, name, whois)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeSettleAuction,
+			sdk.NewAttribute(types.AttributeKeyName, name),
+			sdk.NewAttribute(types.AttributeKeyWinner, whois.Owner.String()),
+			sdk.NewAttribute(types.AttributeKeyPreviousOwner, previousOwner.String()),
+			sdk.NewAttribute(types.AttributeKeyPrice, price.String()),
+			sdk.NewAttribute(types.AttributeKeyProceeds, proceeds.String()),
+			sdk.NewAttribute(types.AttributeKeyProtocolFee, protocolFee.String()),
+			sdk.NewAttribute(types.AttributeKeyExpiryHeight, strconv.FormatInt(whois.ExpiryHeight, 10)),
+		),
+	)
+	return nil
+}
+
+// RebaseAuctionQueue moves every open auction back by height blocks, so that a chain exported for
+// a restart at height zero keeps the remaining commit and reveal phases of each auction
+func (k Keeper) RebaseAuctionQueue(ctx sdk.Context, height int64) {
+	var auctions []types.Auction
+	k.IterateAuctions(ctx, func(auction types.Auction) bool {
+		auctions = append(auctions, auction)
+		return false
+	})
+
+	for _, auction := range auctions {
+		k.DeleteAuction(ctx, auction.Name)
+		auction.CommitEndHeight -= height
+		auction.RevealEndHeight -= height
+		k.SetAuction(ctx, auction)
+	}
+}
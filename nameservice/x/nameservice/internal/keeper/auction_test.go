@@ -0,0 +1,115 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice/internal/types"
+)
+
+func nametokens(amount int64) sdk.Coins {
+	return sdk.NewCoins(sdk.NewInt64Coin("nametoken", amount))
+}
+
+// commitTestBid funds a bidder with deposit, commits their bid and, if amount is positive, reveals it
+func commitTestBid(t *testing.T, ctx sdk.Context, k Keeper, name string, bidder sdk.AccAddress, deposit, amount int64) {
+	_, err := k.CoinKeeper.AddCoins(ctx, bidder, nametokens(deposit))
+	require.Nil(t, err)
+	k.SupplyKeeper.SetSupply(ctx, k.SupplyKeeper.GetSupply(ctx).Inflate(nametokens(deposit)))
+
+	auction, found := k.GetAuction(ctx, name)
+	if !found {
+		auction = types.NewAuction(name, ctx.BlockHeight(), 10, 10)
+	}
+	require.Nil(t, k.CommitBid(ctx, auction, types.SealedBid{Bidder: bidder, BidHash: []byte(bidder), Deposit: nametokens(deposit)}))
+
+	if amount > 0 {
+		auction, _ = k.GetAuction(ctx, name)
+		bid := &auction.Bids[auction.BidIndex(bidder)]
+		bid.Revealed = true
+		bid.Amount = nametokens(amount)
+		k.SetAuction(ctx, auction)
+	}
+}
+
+func TestSettleAuction(t *testing.T) {
+	ctx, k := createTestInput(t)
+
+	winner := sdk.AccAddress(crypto.AddressHash([]byte("winner")))
+	runnerUp := sdk.AccAddress(crypto.AddressHash([]byte("runnerUp")))
+	hidden := sdk.AccAddress(crypto.AddressHash([]byte("hidden")))
+
+	commitTestBid(t, ctx, k, "name", winner, 50, 40)
+	commitTestBid(t, ctx, k, "name", runnerUp, 30, 25)
+	commitTestBid(t, ctx, k, "name", hidden, 20, 0)
+
+	require.Nil(t, k.SettleAuction(ctx, "name"))
+
+	// The winner pays the runner-up's bid out of their deposit and gets the rest back
+	whois := k.GetWhois(ctx, "name")
+	require.Equal(t, winner, whois.Owner)
+	require.Equal(t, nametokens(25), whois.Price)
+	require.Equal(t, ctx.BlockHeight()+types.DefaultParams().NameLifetime, whois.ExpiryHeight)
+	require.Equal(t, nametokens(25), k.CoinKeeper.GetCoins(ctx, winner))
+
+	// Revealed losing bids are refunded in full and unrevealed deposits are burned
+	require.Equal(t, nametokens(30), k.CoinKeeper.GetCoins(ctx, runnerUp))
+	require.True(t, k.CoinKeeper.GetCoins(ctx, hidden).IsZero())
+	require.Equal(t, nametokens(80), k.SupplyKeeper.GetSupply(ctx).GetTotal())
+
+	// Nobody owned the name, so the whole price goes to the fee collector
+	require.Equal(t, nametokens(25), k.SupplyKeeper.GetModuleAccount(ctx, auth.FeeCollectorName).GetCoins())
+	require.True(t, k.SupplyKeeper.GetModuleAccount(ctx, types.AuctionAccountName).GetCoins().IsZero())
+
+	_, found := k.GetAuction(ctx, "name")
+	require.False(t, found)
+	require.NotNil(t, k.SettleAuction(ctx, "name"))
+}
+
+func TestSettleAuctionWithoutWinner(t *testing.T) {
+	ctx, k := createTestInput(t)
+
+	owner := sdk.AccAddress(crypto.AddressHash([]byte("owner")))
+	bidder := sdk.AccAddress(crypto.AddressHash([]byte("bidder")))
+	hidden := sdk.AccAddress(crypto.AddressHash([]byte("hidden")))
+
+	k.SetWhois(ctx, "name", types.Whois{Owner: owner, Price: nametokens(100), ExpiryHeight: 1000})
+	commitTestBid(t, ctx, k, "name", bidder, 60, 50)
+	commitTestBid(t, ctx, k, "name", hidden, 200, 0)
+
+	require.Nil(t, k.SettleAuction(ctx, "name"))
+
+	// No revealed bid met the price, so the owner keeps the name and the revealed bid is refunded
+	whois := k.GetWhois(ctx, "name")
+	require.Equal(t, owner, whois.Owner)
+	require.Equal(t, nametokens(100), whois.Price)
+	require.Equal(t, nametokens(60), k.CoinKeeper.GetCoins(ctx, bidder))
+	require.True(t, k.CoinKeeper.GetCoins(ctx, owner).IsZero())
+
+	// The unrevealed deposit is still burned
+	require.True(t, k.CoinKeeper.GetCoins(ctx, hidden).IsZero())
+	require.Equal(t, nametokens(60), k.SupplyKeeper.GetSupply(ctx).GetTotal())
+	require.True(t, k.SupplyKeeper.GetModuleAccount(ctx, types.AuctionAccountName).GetCoins().IsZero())
+}
+
+func TestSettleAuctionTie(t *testing.T) {
+	ctx, k := createTestInput(t)
+
+	first := sdk.AccAddress(crypto.AddressHash([]byte("first")))
+	second := sdk.AccAddress(crypto.AddressHash([]byte("second")))
+
+	commitTestBid(t, ctx, k, "name", first, 40, 30)
+	commitTestBid(t, ctx, k, "name", second, 40, 30)
+
+	require.Nil(t, k.SettleAuction(ctx, "name"))
+
+	// The bid committed first wins at the tied price
+	require.Equal(t, first, k.GetOwner(ctx, "name"))
+	require.Equal(t, nametokens(10), k.CoinKeeper.GetCoins(ctx, first))
+	require.Equal(t, nametokens(40), k.CoinKeeper.GetCoins(ctx, second))
+	require.Equal(t, nametokens(80), k.SupplyKeeper.GetSupply(ctx).GetTotal())
+}
@@ -0,0 +1,127 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice/internal/types"
+)
+
+// RegisterInvariants registers the nameservice module invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "escrow-balance",
+		EscrowBalanceInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "filled-escrows",
+		FilledEscrowsInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "whois-owners",
+		WhoisOwnersInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "auction-deposits",
+		AuctionDepositsInvariant(k))
+}
+
+// AllInvariants runs all invariants of the nameservice module
+func AllInvariants(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		res, stop := EscrowBalanceInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+		res, stop = FilledEscrowsInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+		res, stop = WhoisOwnersInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+		return AuctionDepositsInvariant(k)(ctx)
+	}
+}
+
+// EscrowBalanceInvariant checks that the escrow module account holds exactly the sum of all escrowed amounts
+func EscrowBalanceInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		escrowed := sdk.NewCoins()
+		k.IterateEscrows(ctx, func(escrow types.Escrow) bool {
+			escrowed = escrowed.Add(escrow.Amount)
+			return false
+		})
+
+		balance := k.SupplyKeeper.GetModuleAccount(ctx, types.EscrowAccountName).GetCoins()
+		// IsEqual panics on coins of the same length with different denominations
+		broken := !escrowed.DenomsSubsetOf(balance) || !escrowed.IsEqual(balance)
+
+		return sdk.FormatInvariant(types.ModuleName, "escrow-balance",
+			fmt.Sprintf("\tsum of escrow amounts: %s\n\tescrow module account balance: %s\n", escrowed, balance)), broken
+	}
+}
+
+// FilledEscrowsInvariant checks that exactly the filled escrows have a customer, and that every escrow holds the
+// merchant's recorded deposit, plus the customer's matching deposit once filled
+func FilledEscrowsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+
+		k.IterateEscrows(ctx, func(escrow types.Escrow) bool {
+			switch {
+			case escrow.Filled && escrow.Customer.Empty():
+				count++
+				msg += fmt.Sprintf("\tfilled escrow %s has no customer\n", escrow.ChannelID)
+			case !escrow.Filled && !escrow.Customer.Empty():
+				count++
+				msg += fmt.Sprintf("\tunfilled escrow %s has customer %s\n", escrow.ChannelID, escrow.Customer)
+			case !escrow.Amount.DenomsSubsetOf(escrow.ExpectedAmount()) || !escrow.Amount.IsEqual(escrow.ExpectedAmount()):
+				count++
+				msg += fmt.Sprintf("\tescrow %s amount %s does not match deposit %s (filled: %t)\n",
+					escrow.ChannelID, escrow.Amount, escrow.Deposit, escrow.Filled)
+			}
+			return false
+		})
+		broken := count != 0
+
+		return sdk.FormatInvariant(types.ModuleName, "filled-escrows",
+			fmt.Sprintf("amount of inconsistent escrows found %d\n%s", count, msg)), broken
+	}
+}
+
+// WhoisOwnersInvariant checks that every stored name has an owner
+func WhoisOwnersInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+
+		iterator := k.GetNamesIterator(ctx)
+		defer iterator.Close()
+		for ; iterator.Valid(); iterator.Next() {
+			var whois types.Whois
+			k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &whois)
+			if whois.Owner.Empty() {
+				count++
+				msg += fmt.Sprintf("\tname %s has no owner\n", types.NameFromWhoisKey(iterator.Key()))
+			}
+		}
+		broken := count != 0
+
+		return sdk.FormatInvariant(types.ModuleName, "whois-owners",
+			fmt.Sprintf("amount of names without an owner found %d\n%s", count, msg)), broken
+	}
+}
+
+// AuctionDepositsInvariant checks that the auction module account holds exactly the sum of the deposits of all open auctions
+func AuctionDepositsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		deposits := sdk.NewCoins()
+		k.IterateAuctions(ctx, func(auction types.Auction) bool {
+			deposits = deposits.Add(auction.Deposits())
+			return false
+		})
+
+		balance := k.SupplyKeeper.GetModuleAccount(ctx, types.AuctionAccountName).GetCoins()
+		// IsEqual panics on coins of the same length with different denominations
+		broken := !deposits.DenomsSubsetOf(balance) || !deposits.IsEqual(balance)
+
+		return sdk.FormatInvariant(types.ModuleName, "auction-deposits",
+			fmt.Sprintf("\tsum of auction deposits: %s\n\tauction module account balance: %s\n", deposits, balance)), broken
+	}
+}
@@ -0,0 +1,113 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice/internal/types"
+)
+
+// fundModuleAccount adds coins to a module account without going through an account that holds them
+func fundModuleAccount(t *testing.T, ctx sdk.Context, k Keeper, moduleName string, coins sdk.Coins) {
+	addr := k.SupplyKeeper.GetModuleAccount(ctx, moduleName).GetAddress()
+	_, err := k.CoinKeeper.AddCoins(ctx, addr, coins)
+	require.Nil(t, err)
+}
+
+func TestEscrowBalanceInvariant(t *testing.T) {
+	ctx, k := createTestInput(t)
+
+	merchant := sdk.AccAddress(crypto.AddressHash([]byte("merchant")))
+	channelID := setTestEscrow(ctx, k, merchant, nil, 0, types.StatusOpen)
+	amount := k.GetEscrow(ctx, channelID).Amount
+
+	// The escrow module account does not hold the escrowed amount
+	_, broken := EscrowBalanceInvariant(k)(ctx)
+	require.True(t, broken)
+
+	fundModuleAccount(t, ctx, k, types.EscrowAccountName, amount)
+	_, broken = EscrowBalanceInvariant(k)(ctx)
+	require.False(t, broken)
+
+	// Coins of another denomination break the invariant instead of panicking
+	ctx, k = createTestInput(t)
+	setTestEscrow(ctx, k, merchant, nil, 0, types.StatusOpen)
+	fundModuleAccount(t, ctx, k, types.EscrowAccountName, sdk.NewCoins(sdk.NewInt64Coin("stake", 10)))
+	_, broken = EscrowBalanceInvariant(k)(ctx)
+	require.True(t, broken)
+}
+
+func TestFilledEscrowsInvariant(t *testing.T) {
+	merchant := sdk.AccAddress(crypto.AddressHash([]byte("merchant")))
+	customer := sdk.AccAddress(crypto.AddressHash([]byte("customer")))
+
+	cases := []struct {
+		name   string
+		modify func(escrow *types.Escrow)
+		broken bool
+	}{
+		{"consistent", func(escrow *types.Escrow) {}, false},
+		{"filled without customer", func(escrow *types.Escrow) {
+			escrow.Customer = nil
+		}, true},
+		{"customer without fill", func(escrow *types.Escrow) {
+			escrow.Filled = false
+		}, true},
+		{"amount not twice the deposit", func(escrow *types.Escrow) {
+			escrow.Amount = escrow.Deposit
+		}, true},
+		{"amount in another denomination", func(escrow *types.Escrow) {
+			escrow.Amount = sdk.NewCoins(sdk.NewInt64Coin("stake", 20))
+		}, true},
+	}
+
+	for _, tc := range cases {
+		ctx, k := createTestInput(t)
+		channelID := setTestEscrow(ctx, k, merchant, customer, 0, types.StatusFilled)
+		escrow := k.GetEscrow(ctx, channelID)
+		tc.modify(&escrow)
+		k.SetEscrow(ctx, channelID, escrow)
+
+		_, broken := FilledEscrowsInvariant(k)(ctx)
+		require.Equal(t, tc.broken, broken, tc.name)
+	}
+}
+
+func TestWhoisOwnersInvariant(t *testing.T) {
+	ctx, k := createTestInput(t)
+
+	owner := sdk.AccAddress(crypto.AddressHash([]byte("owner")))
+	k.SetOwner(ctx, "owned", owner)
+	_, broken := WhoisOwnersInvariant(k)(ctx)
+	require.False(t, broken)
+
+	// SetWhois never stores a name without an owner, so write one directly
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.WhoisKey("unowned"), k.cdc.MustMarshalBinaryBare(types.NewWhois(types.DefaultParams().MinNamePrice)))
+	_, broken = WhoisOwnersInvariant(k)(ctx)
+	require.True(t, broken)
+}
+
+func TestAuctionDepositsInvariant(t *testing.T) {
+	ctx, k := createTestInput(t)
+
+	bidder := sdk.AccAddress(crypto.AddressHash([]byte("bidder")))
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10))
+	_, err := k.CoinKeeper.AddCoins(ctx, bidder, deposit)
+	require.Nil(t, err)
+
+	auction := types.NewAuction("name", ctx.BlockHeight(), 10, 10)
+	require.Nil(t, k.CommitBid(ctx, auction, types.SealedBid{Bidder: bidder, BidHash: []byte("hash"), Deposit: deposit}))
+	_, broken := AuctionDepositsInvariant(k)(ctx)
+	require.False(t, broken)
+
+	// A bid whose deposit never reached the auction module account
+	auction, _ = k.GetAuction(ctx, "name")
+	auction.Bids = append(auction.Bids, types.SealedBid{Bidder: bidder, BidHash: []byte("other"), Deposit: deposit})
+	k.SetAuction(ctx, auction)
+	_, broken = AuctionDepositsInvariant(k)(ctx)
+	require.True(t, broken)
+}
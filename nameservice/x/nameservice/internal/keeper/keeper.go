@@ -1,9 +1,14 @@
 package keeper
 
 import (
+	"encoding/binary"
+
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/supply"
 	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice/internal/types"
 )
 
@@ -11,17 +16,24 @@ import (
 type Keeper struct {
 	CoinKeeper bank.Keeper
 
+	// Moves coins in and out of the escrow module account
+	SupplyKeeper supply.Keeper
+
 	storeKey sdk.StoreKey // Unexposed key to access store from sdk.Context
 
 	cdc *codec.Codec // The wire codec for binary encoding/decoding.
+
+	paramspace params.Subspace
 }
 
 // NewKeeper creates new instances of the nameservice Keeper
-func NewKeeper(coinKeeper bank.Keeper, storeKey sdk.StoreKey, cdc *codec.Codec) Keeper {
+func NewKeeper(coinKeeper bank.Keeper, supplyKeeper supply.Keeper, storeKey sdk.StoreKey, cdc *codec.Codec, paramspace params.Subspace) Keeper {
 	return Keeper{
-		CoinKeeper: coinKeeper,
-		storeKey:   storeKey,
-		cdc:        cdc,
+		CoinKeeper:   coinKeeper,
+		SupplyKeeper: supplyKeeper,
+		storeKey:     storeKey,
+		cdc:          cdc,
+		paramspace:   paramspace.WithKeyTable(types.ParamKeyTable()),
 	}
 }
 
@@ -29,27 +41,53 @@ func NewKeeper(coinKeeper bank.Keeper, storeKey sdk.StoreKey, cdc *codec.Codec)
 func (k Keeper) GetWhois(ctx sdk.Context, name string) types.Whois {
 	store := ctx.KVStore(k.storeKey)
 	if !k.IsNamePresent(ctx, name) {
-		return types.NewWhois()
+		return types.NewWhois(k.GetParams(ctx).MinNamePrice)
 	}
-	bz := store.Get([]byte(name))
+	bz := store.Get(types.WhoisKey(name))
 	var whois types.Whois
 	k.cdc.MustUnmarshalBinaryBare(bz, &whois)
 	return whois
 }
 
-// Sets the entire Whois metadata struct for a name
+// Sets the entire Whois metadata struct for a name and queues the name at its expiry height
 func (k Keeper) SetWhois(ctx sdk.Context, name string, whois types.Whois) {
 	if whois.Owner.Empty() {
 		return
 	}
 	store := ctx.KVStore(k.storeKey)
-	store.Set([]byte(name), k.cdc.MustMarshalBinaryBare(whois))
+	if k.IsNamePresent(ctx, name) {
+		store.Delete(types.ExpiryQueueKey(k.GetWhois(ctx, name).ExpiryHeight, name))
+	} else {
+		k.setNameCount(ctx, k.GetNameCount(ctx)+1)
+	}
+	store.Set(types.WhoisKey(name), k.cdc.MustMarshalBinaryBare(whois))
+	store.Set(types.ExpiryQueueKey(whois.ExpiryHeight, name), []byte(name))
 }
 
-// Deletes the entire Whois metadata struct for a name
+// Deletes the entire Whois metadata struct for a name and removes it from the expiry queue
 func (k Keeper) DeleteWhois(ctx sdk.Context, name string) {
 	store := ctx.KVStore(k.storeKey)
-	store.Delete([]byte(name))
+	if k.IsNamePresent(ctx, name) {
+		store.Delete(types.ExpiryQueueKey(k.GetWhois(ctx, name).ExpiryHeight, name))
+		k.setNameCount(ctx, k.GetNameCount(ctx)-1)
+	}
+	store.Delete(types.WhoisKey(name))
+}
+
+// GetNameCount returns the number of owned names
+func (k Keeper) GetNameCount(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.NameCountKey)
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// setNameCount sets the number of owned names
+func (k Keeper) setNameCount(ctx sdk.Context, count uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.NameCountKey, sdk.Uint64ToBigEndian(count))
 }
 
 // ResolveName - returns the string that the name resolves to
@@ -93,61 +131,200 @@ func (k Keeper) SetPrice(ctx sdk.Context, name string, price sdk.Coins) {
 	k.SetWhois(ctx, name, whois)
 }
 
-// Get an iterator over all names in which the keys are the names and the values are the whois
+// SetExpiryHeight - sets the height a name expires at
+func (k Keeper) SetExpiryHeight(ctx sdk.Context, name string, expiryHeight int64) {
+	whois := k.GetWhois(ctx, name)
+	whois.ExpiryHeight = expiryHeight
+	k.SetWhois(ctx, name, whois)
+}
+
+// ExpiryQueueIterator returns an iterator over the expiry queue up to and including height.
+// The values are the names that expired at or before height
+func (k Keeper) ExpiryQueueIterator(ctx sdk.Context, height int64) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return store.Iterator(types.ExpiryQueuePrefix, types.ExpiryQueueHeightKey(height+1))
+}
+
+// RenewName charges the owner of a name the renewal fee, paid to the fee collector, and extends the name
+// by the name lifetime from its expiry, or from height if it has already expired
+func (k Keeper) RenewName(ctx sdk.Context, name string, height int64) (int64, sdk.Error) {
+	params := k.GetParams(ctx)
+	whois := k.GetWhois(ctx, name)
+
+	if !params.NameRenewalFee.IsZero() {
+		err := k.SupplyKeeper.SendCoinsFromAccountToModule(ctx, whois.Owner, auth.FeeCollectorName, params.NameRenewalFee)
+		if err != nil {
+			return 0, sdk.ErrInsufficientCoins("Owner does not have enough coins to pay the renewal fee")
+		}
+	}
+
+	expiryHeight := whois.ExpiryHeight
+	if whois.IsExpired(height) {
+		expiryHeight = height
+	}
+	expiryHeight += params.NameLifetime
+	k.SetExpiryHeight(ctx, name, expiryHeight)
+	return expiryHeight, nil
+}
+
+// SplitProceeds splits the proceeds of a name sale into the share of the previous owner and the protocol fee
+// paid to the fee collector. A name nobody owns pays the whole price as protocol fee, a resale pays the resale
+// fee rate of it, rounded down
+func (k Keeper) SplitProceeds(ctx sdk.Context, previousOwner sdk.AccAddress, proceeds sdk.Coins) (ownerShare sdk.Coins, protocolFee sdk.Coins) {
+	if previousOwner.Empty() {
+		return sdk.NewCoins(), proceeds
+	}
+
+	rate := k.GetParams(ctx).ResaleFeeRate
+	protocolFee = sdk.NewCoins()
+	for _, coin := range proceeds {
+		protocolFee = protocolFee.Add(sdk.NewCoins(sdk.NewCoin(coin.Denom, rate.MulInt(coin.Amount).TruncateInt())))
+	}
+	return proceeds.Sub(protocolFee), protocolFee
+}
+
+// Get an iterator over all names in which the keys are the Whois keys and the values are the whois
 func (k Keeper) GetNamesIterator(ctx sdk.Context) sdk.Iterator {
 	store := ctx.KVStore(k.storeKey)
-	return sdk.KVStorePrefixIterator(store, nil)
+	return sdk.KVStorePrefixIterator(store, types.WhoisPrefix)
 }
 
 // Check if the name is present in the store or not
 func (k Keeper) IsNamePresent(ctx sdk.Context, name string) bool {
 	store := ctx.KVStore(k.storeKey)
-	return store.Has([]byte(name))
+	return store.Has(types.WhoisKey(name))
 }
 
-// SetEscrow an escrow account. Currently uses the sender address (which means only one escrow account per user) as a key. This should be changed (and obfuscated) in a clever way
-func (k Keeper) SetEscrow(ctx sdk.Context, senderAddress string, escrow types.Escrow) {
+// SetEscrow an escrow account, keyed by its channel ID
+func (k Keeper) SetEscrow(ctx sdk.Context, channelID string, escrow types.Escrow) {
 	if escrow.Amount.Empty() {
 		return
 	}
+	if k.IsEscrowPresent(ctx, channelID) {
+		k.removeEscrowIndexes(ctx, channelID, k.GetEscrow(ctx, channelID))
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.EscrowKey(channelID), k.cdc.MustMarshalBinaryBare(escrow))
+	k.setEscrowIndexes(ctx, channelID, escrow)
+}
+
+// DeleteEscrow removes an escrow account and its index entries from the store
+func (k Keeper) DeleteEscrow(ctx sdk.Context, channelID string) {
+	if k.IsEscrowPresent(ctx, channelID) {
+		k.removeEscrowIndexes(ctx, channelID, k.GetEscrow(ctx, channelID))
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.EscrowKey(channelID))
+}
+
+// setEscrowIndexes adds an escrow to the merchant, customer and status indexes. The values are the channel ID
+func (k Keeper) setEscrowIndexes(ctx sdk.Context, channelID string, escrow types.Escrow) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(append(types.MerchantIndexKey(escrow.Merchant), []byte(channelID)...), []byte(channelID))
+	if !escrow.Customer.Empty() {
+		store.Set(append(types.CustomerIndexKey(escrow.Customer), []byte(channelID)...), []byte(channelID))
+	}
+	store.Set(append(types.StatusIndexKey(escrow.Status()), []byte(channelID)...), []byte(channelID))
+	for _, indexKey := range orderCountIndexKeys(escrow) {
+		k.addOrderCount(ctx, indexKey, 1)
+	}
+}
+
+// removeEscrowIndexes removes an escrow from the merchant, customer and status indexes
+func (k Keeper) removeEscrowIndexes(ctx sdk.Context, channelID string, escrow types.Escrow) {
 	store := ctx.KVStore(k.storeKey)
-	store.Set([]byte(senderAddress), k.cdc.MustMarshalBinaryBare(escrow))
+	store.Delete(append(types.MerchantIndexKey(escrow.Merchant), []byte(channelID)...))
+	if !escrow.Customer.Empty() {
+		store.Delete(append(types.CustomerIndexKey(escrow.Customer), []byte(channelID)...))
+	}
+	store.Delete(append(types.StatusIndexKey(escrow.Status()), []byte(channelID)...))
+	for _, indexKey := range orderCountIndexKeys(escrow) {
+		k.addOrderCount(ctx, indexKey, -1)
+	}
+}
+
+// orderCountIndexKeys returns every index prefix an escrow is counted under
+func orderCountIndexKeys(escrow types.Escrow) [][]byte {
+	status := []byte(escrow.Status())
+	indexKeys := [][]byte{
+		types.EscrowPrefix,
+		types.StatusIndexKey(escrow.Status()),
+		types.MerchantIndexKey(escrow.Merchant),
+		append(types.MerchantIndexKey(escrow.Merchant), status...),
+	}
+	if !escrow.Customer.Empty() {
+		indexKeys = append(indexKeys,
+			types.CustomerIndexKey(escrow.Customer),
+			append(types.CustomerIndexKey(escrow.Customer), status...))
+	}
+	return indexKeys
 }
 
-// Get the Escrow data for a sender address
-// func (k Keeper) GetEscrow(ctx sdk.Context, senderAddress string) Escrow {
-// 	store := ctx.KVStore(k.storeKey)
-// 	if !k.IsEscrowPresent(ctx, senderAddress) {
-// 		return NewEscrow() // FIXME, maybe throw error?
-// 	}
-// }
+// GetOrderCount returns the number of escrows under an escrow index prefix
+func (k Keeper) GetOrderCount(ctx sdk.Context, indexKey []byte) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.OrderCountKey(indexKey))
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
 
-// IsEscrowPresent checks if an Escrow account exists for the given sender address
-func (k Keeper) IsEscrowPresent(ctx sdk.Context, senderAddress string) bool {
+// addOrderCount adds delta to the number of escrows under an escrow index prefix. Counts that reach zero are deleted
+func (k Keeper) addOrderCount(ctx sdk.Context, indexKey []byte, delta int64) {
 	store := ctx.KVStore(k.storeKey)
-	return store.Has([]byte(senderAddress))
+	count := int64(k.GetOrderCount(ctx, indexKey)) + delta
+	if count <= 0 {
+		store.Delete(types.OrderCountKey(indexKey))
+		return
+	}
+	store.Set(types.OrderCountKey(indexKey), sdk.Uint64ToBigEndian(uint64(count)))
+}
+
+// GetMerchantEscrowsIterator returns an iterator over the channel IDs of a merchant's escrows
+func (k Keeper) GetMerchantEscrowsIterator(ctx sdk.Context, merchant sdk.AccAddress) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.MerchantIndexKey(merchant))
+}
+
+// GetCustomerEscrowsIterator returns an iterator over the channel IDs of the escrows a customer has filled
+func (k Keeper) GetCustomerEscrowsIterator(ctx sdk.Context, customer sdk.AccAddress) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.CustomerIndexKey(customer))
+}
+
+// GetStatusEscrowsIterator returns an iterator over the channel IDs of the escrows with a status
+func (k Keeper) GetStatusEscrowsIterator(ctx sdk.Context, status string) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.StatusIndexKey(status))
+}
+
+// IsEscrowPresent checks if an Escrow account exists for the given channel ID
+func (k Keeper) IsEscrowPresent(ctx sdk.Context, channelID string) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.EscrowKey(channelID))
 }
 
 // IsEscrowFilled checks if an Escrow account has been filled
-func (k Keeper) IsEscrowFilled(ctx sdk.Context, senderAddress string) bool {
-	return k.GetEscrow(ctx, senderAddress).Filled
+func (k Keeper) IsEscrowFilled(ctx sdk.Context, channelID string) bool {
+	return k.GetEscrow(ctx, channelID).Filled
 }
 
-// GetEscrow returns an escrow account given a key
-func (k Keeper) GetEscrow(ctx sdk.Context, senderAddress string) types.Escrow {
+// GetEscrow returns an escrow account given its channel ID
+func (k Keeper) GetEscrow(ctx sdk.Context, channelID string) types.Escrow {
 	store := ctx.KVStore(k.storeKey)
-	if !k.IsEscrowPresent(ctx, senderAddress) {
+	if !k.IsEscrowPresent(ctx, channelID) {
 		return types.NewEscrow()
 	}
-	binary := store.Get([]byte(senderAddress))
+	bz := store.Get(types.EscrowKey(channelID))
 	var escrow types.Escrow
-	k.cdc.MustUnmarshalBinaryBare(binary, &escrow)
+	k.cdc.MustUnmarshalBinaryBare(bz, &escrow)
 	return escrow
 }
 
 // GetEscrowSize returns an escrows contract size
-func (k Keeper) GetEscrowSize(ctx sdk.Context, senderAddress string) sdk.Int {
-	escrow := k.GetEscrow(ctx, senderAddress)
+func (k Keeper) GetEscrowSize(ctx sdk.Context, channelID string) sdk.Int {
+	escrow := k.GetEscrow(ctx, channelID)
 	if escrow.Filled {
 		return escrow.Amount[0].Amount.QuoRaw(2)
 	}
@@ -155,22 +332,186 @@ func (k Keeper) GetEscrowSize(ctx sdk.Context, senderAddress string) sdk.Int {
 }
 
 // GetEscrowDenom returns an escrows denomination
-func (k Keeper) GetEscrowDenom(ctx sdk.Context, senderAddress string) string {
-	return k.GetEscrow(ctx, senderAddress).Amount[0].Denom
+func (k Keeper) GetEscrowDenom(ctx sdk.Context, channelID string) string {
+	return k.GetEscrow(ctx, channelID).Amount[0].Denom
 }
 
 // SetCustomer adds a customer to the escrow account
-func (k Keeper) SetCustomer(ctx sdk.Context, senderAddress string, customer sdk.AccAddress, walletCommit []byte, coins sdk.Coins) {
-	escrow := k.GetEscrow(ctx, senderAddress)
+func (k Keeper) SetCustomer(ctx sdk.Context, channelID string, customer sdk.AccAddress, walletCommit []byte, coins sdk.Coins) {
+	escrow := k.GetEscrow(ctx, channelID)
 	escrow.Customer = customer
 	escrow.WalletCommit = walletCommit
-	escrow.Amount.Add(coins)
+	escrow.Amount = escrow.Amount.Add(coins)
 	escrow.Filled = true
-	k.SetEscrow(ctx, senderAddress, escrow)
+	k.SetEscrow(ctx, channelID, escrow)
+}
+
+// GetChannelSequence returns the number of channels a merchant has opened
+func (k Keeper) GetChannelSequence(ctx sdk.Context, merchant sdk.AccAddress) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.ChannelSequenceKey(merchant))
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// SetChannelSequence sets the number of channels a merchant has opened
+func (k Keeper) SetChannelSequence(ctx sdk.Context, merchant sdk.AccAddress, sequence uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.ChannelSequenceKey(merchant), sdk.Uint64ToBigEndian(sequence))
+}
+
+// IterateChannelSequences calls cb with the channel sequence of every merchant until cb returns true
+func (k Keeper) IterateChannelSequences(ctx sdk.Context, cb func(merchant sdk.AccAddress, sequence uint64) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	itr := sdk.KVStorePrefixIterator(store, types.ChannelSequencePrefix)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		merchant := sdk.AccAddress(itr.Key()[len(types.ChannelSequencePrefix):])
+		if cb(merchant, binary.BigEndian.Uint64(itr.Value())) {
+			break
+		}
+	}
+}
+
+// NextChannelID derives the channel ID for a merchant's next channel and increments their channel sequence
+func (k Keeper) NextChannelID(ctx sdk.Context, merchant sdk.AccAddress, channelToken []byte) string {
+	sequence := k.GetChannelSequence(ctx, merchant)
+	k.SetChannelSequence(ctx, merchant, sequence+1)
+	return types.NewChannelID(merchant, channelToken, sequence)
 }
 
 // GetAllEscrows lets you see all "orders" on chain
 func (k Keeper) GetAllEscrows(ctx sdk.Context) sdk.Iterator {
 	store := ctx.KVStore(k.storeKey)
-	return sdk.KVStorePrefixIterator(store, []byte{})
+	return sdk.KVStorePrefixIterator(store, types.EscrowPrefix)
+}
+
+// IterateEscrows calls cb with every escrow until cb returns true
+func (k Keeper) IterateEscrows(ctx sdk.Context, cb func(escrow types.Escrow) (stop bool)) {
+	itr := k.GetAllEscrows(ctx)
+	defer itr.Close()
+	for ; itr.Valid(); itr.Next() {
+		var escrow types.Escrow
+		k.cdc.MustUnmarshalBinaryBare(itr.Value(), &escrow)
+		if cb(escrow) {
+			break
+		}
+	}
+}
+
+// PayoutEscrow releases the escrowed coins from the escrow module account to the merchant and customer
+// according to the balance, then removes the escrow
+func (k Keeper) PayoutEscrow(ctx sdk.Context, channelID string, balance types.ChannelBalance) sdk.Error {
+	escrow := k.GetEscrow(ctx, channelID)
+	if !balance.MerchantBalance.Empty() {
+		err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, types.EscrowAccountName, escrow.Merchant, balance.MerchantBalance)
+		if err != nil {
+			return err
+		}
+	}
+	if !balance.CustomerBalance.Empty() {
+		err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, types.EscrowAccountName, escrow.Customer, balance.CustomerBalance)
+		if err != nil {
+			return err
+		}
+	}
+	if escrow.IsClosing() {
+		k.RemoveFromClosingQueue(ctx, escrow.Close.MatureHeight, channelID)
+	}
+	k.DeleteEscrow(ctx, channelID)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypePayout,
+			sdk.NewAttribute(types.AttributeKeyChannelID, channelID),
+			sdk.NewAttribute(types.AttributeKeyMerchant, escrow.Merchant.String()),
+			sdk.NewAttribute(types.AttributeKeyMerchantBalance, balance.MerchantBalance.String()),
+			sdk.NewAttribute(types.AttributeKeyCustomer, escrow.Customer.String()),
+			sdk.NewAttribute(types.AttributeKeyCustomerBalance, balance.CustomerBalance.String()),
+		),
+	)
+	return nil
+}
+
+// GetDisputePeriod returns the number of blocks a close waits before the escrow is paid out
+func (k Keeper) GetDisputePeriod(ctx sdk.Context) int64 {
+	return k.GetParams(ctx).DisputePeriod
+}
+
+// StartClose puts a filled escrow into the closing state and queues it to be paid out after the dispute period
+func (k Keeper) StartClose(ctx sdk.Context, channelID string, balance types.ChannelBalance, initiator sdk.AccAddress) types.PendingClose {
+	escrow := k.GetEscrow(ctx, channelID)
+	pending := types.NewPendingClose(balance, initiator, ctx.BlockHeight(), ctx.BlockTime(), k.GetDisputePeriod(ctx))
+	escrow.Close = &pending
+	k.SetEscrow(ctx, channelID, escrow)
+	k.InsertClosingQueue(ctx, pending.MatureHeight, channelID)
+	return pending
+}
+
+// InsertClosingQueue adds an escrow to the closing queue at the height its dispute period ends
+func (k Keeper) InsertClosingQueue(ctx sdk.Context, matureHeight int64, channelID string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.ClosingQueueKey(matureHeight, channelID), []byte(channelID))
+}
+
+// RemoveFromClosingQueue removes an escrow from the closing queue
+func (k Keeper) RemoveFromClosingQueue(ctx sdk.Context, matureHeight int64, channelID string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.ClosingQueueKey(matureHeight, channelID))
+}
+
+// ClosingQueueIterator returns an iterator over the closing queue up to and including height.
+// The values are the keys of the escrows whose dispute period has ended
+func (k Keeper) ClosingQueueIterator(ctx sdk.Context, height int64) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return store.Iterator(types.ClosingQueuePrefix, types.ClosingQueueHeightKey(height+1))
+}
+
+// RebaseClosingQueue moves every pending close back by height blocks, so that a chain exported for
+// a restart at height zero keeps the remaining dispute period of each close. Heights that would not be
+// positive become height one, so a close that has already matured is paid out in the first block
+func (k Keeper) RebaseClosingQueue(ctx sdk.Context, height int64) {
+	var closing []types.Escrow
+	k.IterateEscrows(ctx, func(escrow types.Escrow) bool {
+		if escrow.IsClosing() {
+			closing = append(closing, escrow)
+		}
+		return false
+	})
+
+	for _, escrow := range closing {
+		k.RemoveFromClosingQueue(ctx, escrow.Close.MatureHeight, escrow.ChannelID)
+		escrow.Close.Height -= height
+		if escrow.Close.Height < 1 {
+			escrow.Close.Height = 1
+		}
+		escrow.Close.MatureHeight -= height
+		if escrow.Close.MatureHeight < 1 {
+			escrow.Close.MatureHeight = 1
+		}
+		k.SetEscrow(ctx, escrow.ChannelID, escrow)
+		k.InsertClosingQueue(ctx, escrow.Close.MatureHeight, escrow.ChannelID)
+	}
+}
+
+// RebaseExpiryQueue moves the expiry of every name back by height blocks, so that a chain exported for
+// a restart at height zero keeps the remaining lifetime of each name. Names that have already expired
+// expire at height one, since an owned name must have a positive expiry height
+func (k Keeper) RebaseExpiryQueue(ctx sdk.Context, height int64) {
+	var names []string
+	itr := k.GetNamesIterator(ctx)
+	for ; itr.Valid(); itr.Next() {
+		names = append(names, types.NameFromWhoisKey(itr.Key()))
+	}
+	itr.Close()
+
+	for _, name := range names {
+		expiryHeight := k.GetWhois(ctx, name).ExpiryHeight - height
+		if expiryHeight < 1 {
+			expiryHeight = 1
+		}
+		k.SetExpiryHeight(ctx, name, expiryHeight)
+	}
 }
@@ -0,0 +1,44 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestSplitProceeds(t *testing.T) {
+	ctx, k := createTestInput(t)
+	owner := sdk.AccAddress(crypto.AddressHash([]byte("owner")))
+
+	cases := []struct {
+		name          string
+		previousOwner sdk.AccAddress
+		rate          sdk.Dec
+		proceeds      int64
+		ownerShare    int64
+		protocolFee   int64
+	}{
+		{"unowned name pays the whole price as fee", nil, sdk.NewDecWithPrec(5, 2), 101, 0, 101},
+		{"resale pays the resale fee rate", owner, sdk.NewDecWithPrec(5, 2), 200, 190, 10},
+		{"odd amounts round the fee down", owner, sdk.NewDecWithPrec(5, 2), 39, 38, 1},
+		{"fee below one token rounds to nothing", owner, sdk.NewDecWithPrec(5, 2), 19, 19, 0},
+		{"half of an odd amount rounds to the owner", owner, sdk.NewDecWithPrec(5, 1), 7, 4, 3},
+		{"zero rate pays the owner in full", owner, sdk.ZeroDec(), 200, 200, 0},
+		{"zero rate still pays an unowned name as fee", nil, sdk.ZeroDec(), 200, 0, 200},
+		{"full rate pays the owner nothing", owner, sdk.OneDec(), 200, 0, 200},
+	}
+
+	for _, tc := range cases {
+		params := k.GetParams(ctx)
+		params.ResaleFeeRate = tc.rate
+		k.SetParams(ctx, params)
+
+		ownerShare, protocolFee := k.SplitProceeds(ctx, tc.previousOwner, nametokens(tc.proceeds))
+		require.True(t, ownerShare.IsEqual(nametokens(tc.ownerShare)), tc.name)
+		require.True(t, protocolFee.IsEqual(nametokens(tc.protocolFee)), tc.name)
+		require.Equal(t, nametokens(tc.proceeds), ownerShare.Add(protocolFee), tc.name)
+	}
+}
@@ -0,0 +1,236 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice/internal/types"
+)
+
+// legacyEscrow is the layout escrows were stored with before channel IDs, keyed by the merchant's bech32 address
+type legacyEscrow struct {
+	Merchant     sdk.AccAddress
+	Customer     sdk.AccAddress
+	ChannelState string
+	ChannelToken string
+	WalletCommit []byte
+	Amount       sdk.Coins
+	Filled       bool
+}
+
+// GetStoreVersion returns the version of the key schema the store has been migrated to
+func (k Keeper) GetStoreVersion(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.StoreVersionKey)
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// SetStoreVersion records the version of the key schema the store is using
+func (k Keeper) SetStoreVersion(ctx sdk.Context, version uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.StoreVersionKey, sdk.Uint64ToBigEndian(version))
+}
+
+// MigrateStore brings a store written by an older version of the module up to the current StoreVersion.
+// It is a no-op once the store is up to date
+func (k Keeper) MigrateStore(ctx sdk.Context) {
+	version := k.GetStoreVersion(ctx)
+	if version >= types.StoreVersion {
+		return
+	}
+
+	if version < 1 {
+		k.migrateKeySchema(ctx)
+	}
+	if version < 2 {
+		// Params did not exist yet, so start from the defaults
+		k.SetParams(ctx, types.DefaultParams())
+	} else {
+		// Keep the params already set and default the ones added since
+		defaults := types.DefaultParams()
+		if version < 3 {
+			k.paramspace.Set(ctx, types.KeyMinNamePrice, defaults.MinNamePrice)
+			k.paramspace.Set(ctx, types.KeyAllowedEscrowDenoms, defaults.AllowedEscrowDenoms)
+			k.paramspace.Set(ctx, types.KeyMinEscrowAmount, defaults.MinEscrowAmount)
+			k.paramspace.Set(ctx, types.KeyMaxEscrowAmount, defaults.MaxEscrowAmount)
+			k.paramspace.Set(ctx, types.KeyDisputePeriod, defaults.DisputePeriod)
+		}
+		if version < 5 {
+			k.paramspace.Set(ctx, types.KeyNameLifetime, defaults.NameLifetime)
+			k.paramspace.Set(ctx, types.KeyNameGracePeriod, defaults.NameGracePeriod)
+			k.paramspace.Set(ctx, types.KeyNameRenewalFee, defaults.NameRenewalFee)
+		}
+		if version < 6 {
+			k.paramspace.Set(ctx, types.KeyAuctionOnly, defaults.AuctionOnly)
+			k.paramspace.Set(ctx, types.KeyAuctionCommitPeriod, defaults.AuctionCommitPeriod)
+			k.paramspace.Set(ctx, types.KeyAuctionRevealPeriod, defaults.AuctionRevealPeriod)
+		}
+		if version < 7 {
+			k.paramspace.Set(ctx, types.KeyResaleFeeRate, defaults.ResaleFeeRate)
+		}
+	}
+	if version < 4 {
+		k.migrateEscrowDeposits(ctx)
+	}
+	if version < 5 {
+		k.migrateNameExpiry(ctx)
+	}
+	if version < 9 {
+		k.migrateEscrowIndexes(ctx)
+	}
+	if version < 10 {
+		k.migrateNameCount(ctx)
+	}
+
+	k.SetStoreVersion(ctx, types.StoreVersion)
+}
+
+// migrateKeySchema moves a store written without key prefixes onto the current key schema.
+// Every unprefixed key is either an escrow keyed by its merchant's bech32 address or a Whois keyed by its name.
+// Escrows are given the channel ID of their merchant's first channel. Legacy escrows burned their coins from
+// the merchant and customer, so their amounts are added back to the escrow module account and the total supply
+// to be paid out from. The escrow module account is not a minter, since nothing else may create coins in it
+func (k Keeper) migrateKeySchema(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+
+	// Collect everything first, since the store cannot be written to while it is being iterated
+	var keys, values [][]byte
+	itr := store.Iterator(nil, nil)
+	for ; itr.Valid(); itr.Next() {
+		keys = append(keys, itr.Key())
+		values = append(values, itr.Value())
+	}
+	itr.Close()
+
+	escrowed := sdk.NewCoins()
+	for i, key := range keys {
+		store.Delete(key)
+
+		if escrow, ok := k.decodeLegacyEscrow(key, values[i]); ok {
+			// Legacy channel states were hex encoded public keys, and tokens arbitrary strings
+			channelState, err := hex.DecodeString(escrow.ChannelState)
+			if err != nil {
+				channelState = []byte(escrow.ChannelState)
+			}
+			channelToken := []byte(escrow.ChannelToken)
+			channelID := types.NewChannelID(escrow.Merchant, channelToken, 0)
+			k.SetChannelSequence(ctx, escrow.Merchant, 1)
+			k.SetEscrow(ctx, channelID, types.Escrow{
+				ChannelID:    channelID,
+				Merchant:     escrow.Merchant,
+				Customer:     escrow.Customer,
+				ChannelState: channelState,
+				ChannelToken: channelToken,
+				WalletCommit: escrow.WalletCommit,
+				Amount:       escrow.Amount,
+				Filled:       escrow.Filled,
+			})
+			escrowed = escrowed.Add(escrow.Amount)
+			continue
+		}
+
+		var whois types.Whois
+		k.cdc.MustUnmarshalBinaryBare(values[i], &whois)
+		k.SetWhois(ctx, string(key), whois)
+	}
+
+	if !escrowed.Empty() {
+		escrowAcc := k.SupplyKeeper.GetModuleAccount(ctx, types.EscrowAccountName)
+		if _, err := k.CoinKeeper.AddCoins(ctx, escrowAcc.GetAddress(), escrowed); err != nil {
+			panic(err)
+		}
+		k.SupplyKeeper.SetSupply(ctx, k.SupplyKeeper.GetSupply(ctx).Inflate(escrowed))
+	}
+}
+
+// migrateEscrowDeposits records the merchant deposit of every escrow, which is the whole amount of an
+// unfilled escrow and half the amount of a filled one
+func (k Keeper) migrateEscrowDeposits(ctx sdk.Context) {
+	var escrows []types.Escrow
+	k.IterateEscrows(ctx, func(escrow types.Escrow) bool {
+		escrows = append(escrows, escrow)
+		return false
+	})
+
+	for _, escrow := range escrows {
+		escrow.Deposit = escrow.Amount
+		if escrow.Filled {
+			escrow.Deposit = sdk.NewCoins()
+			for _, coin := range escrow.Amount {
+				escrow.Deposit = escrow.Deposit.Add(sdk.NewCoins(sdk.NewCoin(coin.Denom, coin.Amount.QuoRaw(2))))
+			}
+		}
+		k.SetEscrow(ctx, escrow.ChannelID, escrow)
+	}
+}
+
+// migrateNameExpiry gives every name, which were owned forever before, a full name lifetime from now
+func (k Keeper) migrateNameExpiry(ctx sdk.Context) {
+	var names []string
+	itr := k.GetNamesIterator(ctx)
+	for ; itr.Valid(); itr.Next() {
+		names = append(names, types.NameFromWhoisKey(itr.Key()))
+	}
+	itr.Close()
+
+	expiryHeight := ctx.BlockHeight() + k.GetParams(ctx).NameLifetime
+	for _, name := range names {
+		k.SetExpiryHeight(ctx, name, expiryHeight)
+	}
+}
+
+// migrateEscrowIndexes rebuilds the merchant, customer and status indexes and their order counts from
+// the stored escrows, which were written without index entries before the order queries existed
+func (k Keeper) migrateEscrowIndexes(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+
+	var stale [][]byte
+	for _, prefix := range [][]byte{types.MerchantIndexPrefix, types.CustomerIndexPrefix, types.StatusIndexPrefix, types.OrderCountPrefix} {
+		itr := sdk.KVStorePrefixIterator(store, prefix)
+		for ; itr.Valid(); itr.Next() {
+			stale = append(stale, itr.Key())
+		}
+		itr.Close()
+	}
+	for _, key := range stale {
+		store.Delete(key)
+	}
+
+	var escrows []types.Escrow
+	k.IterateEscrows(ctx, func(escrow types.Escrow) bool {
+		escrows = append(escrows, escrow)
+		return false
+	})
+	for _, escrow := range escrows {
+		k.setEscrowIndexes(ctx, escrow.ChannelID, escrow)
+	}
+}
+
+// migrateNameCount counts the owned names, which were not counted before the names query returned a total
+func (k Keeper) migrateNameCount(ctx sdk.Context) {
+	var count uint64
+	itr := k.GetNamesIterator(ctx)
+	for ; itr.Valid(); itr.Next() {
+		count++
+	}
+	itr.Close()
+
+	k.setNameCount(ctx, count)
+}
+
+// decodeLegacyEscrow returns the escrow stored under a legacy key, if the key is the address of the escrow's merchant
+func (k Keeper) decodeLegacyEscrow(key []byte, value []byte) (legacyEscrow, bool) {
+	var escrow legacyEscrow
+	merchant, err := sdk.AccAddressFromBech32(string(key))
+	if err != nil {
+		return escrow, false
+	}
+	if err := k.cdc.UnmarshalBinaryBare(value, &escrow); err != nil {
+		return escrow, false
+	}
+	return escrow, merchant.Equals(escrow.Merchant)
+}
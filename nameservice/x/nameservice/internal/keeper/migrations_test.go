@@ -0,0 +1,113 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice/internal/types"
+)
+
+// createTestInput returns a context and a nameservice keeper backed by in-memory stores
+func createTestInput(t *testing.T) (sdk.Context, Keeper) {
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keySupply := sdk.NewKVStoreKey(supply.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+	keyNameservice := sdk.NewKVStoreKey(types.StoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySupply, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	ms.MountStoreWithDB(keyNameservice, sdk.StoreTypeIAVL, db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	auth.RegisterCodec(cdc)
+	supply.RegisterCodec(cdc)
+	types.RegisterCodec(cdc)
+	sdk.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "nameservice", Height: 1}, false, log.NewNopLogger())
+
+	maccPerms := map[string][]string{
+		auth.FeeCollectorName:    nil,
+		types.EscrowAccountName:  nil,
+		types.AuctionAccountName: {supply.Burner},
+	}
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams, params.DefaultCodespace)
+	ak := auth.NewAccountKeeper(cdc, keyAcc, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	bk := bank.NewBaseKeeper(ak, pk.Subspace(bank.DefaultParamspace), bank.DefaultCodespace, nil)
+	sk := supply.NewKeeper(cdc, keySupply, ak, bk, maccPerms)
+	sk.SetSupply(ctx, supply.NewSupply(sdk.NewCoins()))
+
+	k := NewKeeper(bk, sk, keyNameservice, cdc, pk.Subspace(types.DefaultParamspace))
+	k.SetParams(ctx, types.DefaultParams())
+	return ctx, k
+}
+
+func TestMigrateKeySchemaPaysOutLegacyEscrow(t *testing.T) {
+	ctx, k := createTestInput(t)
+
+	merchant := sdk.AccAddress(crypto.AddressHash([]byte("merchant")))
+	customer := sdk.AccAddress(crypto.AddressHash([]byte("customer")))
+	amount := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 20))
+
+	// Write a filled escrow and a name the way the unprefixed store held them
+	store := ctx.KVStore(k.storeKey)
+	store.Set([]byte(merchant.String()), k.cdc.MustMarshalBinaryBare(legacyEscrow{
+		Merchant:     merchant,
+		Customer:     customer,
+		ChannelState: "00",
+		ChannelToken: "token",
+		Amount:       amount,
+		Filled:       true,
+	}))
+	store.Set([]byte("name"), k.cdc.MustMarshalBinaryBare(types.Whois{
+		Value: "value",
+		Owner: customer,
+		Price: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1)),
+	}))
+
+	k.MigrateStore(ctx)
+	require.Equal(t, types.StoreVersion, k.GetStoreVersion(ctx))
+	require.Equal(t, "value", k.ResolveName(ctx, "name"))
+	require.Equal(t, uint64(1), k.GetNameCount(ctx))
+
+	channelID := types.NewChannelID(merchant, []byte("token"), 0)
+	require.True(t, k.IsEscrowPresent(ctx, channelID))
+	escrow := k.GetEscrow(ctx, channelID)
+	require.Equal(t, amount, escrow.Amount)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10)), escrow.Deposit)
+	require.Equal(t, amount, k.SupplyKeeper.GetModuleAccount(ctx, types.EscrowAccountName).GetCoins())
+	require.Equal(t, amount, k.SupplyKeeper.GetSupply(ctx).GetTotal())
+
+	_, broken := EscrowBalanceInvariant(k)(ctx)
+	require.False(t, broken)
+	_, broken = FilledEscrowsInvariant(k)(ctx)
+	require.False(t, broken)
+
+	balance := types.NewChannelBalance(channelID, 1,
+		sdk.NewCoins(sdk.NewInt64Coin("nametoken", 15)), sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5)))
+	require.Nil(t, k.PayoutEscrow(ctx, channelID, balance))
+
+	require.False(t, k.IsEscrowPresent(ctx, channelID))
+	require.Equal(t, balance.MerchantBalance, k.CoinKeeper.GetCoins(ctx, merchant))
+	require.Equal(t, balance.CustomerBalance, k.CoinKeeper.GetCoins(ctx, customer))
+	require.True(t, k.SupplyKeeper.GetModuleAccount(ctx, types.EscrowAccountName).GetCoins().IsZero())
+}
@@ -0,0 +1,31 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice/internal/types"
+)
+
+// GetParams returns the module's params
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	var params types.Params
+	k.paramspace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams sets the module's params
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramspace.SetParamSet(ctx, &params)
+}
+
+// ConsumeBlsPubKeyGas charges the gas for deserializing numKeys public keys, before they are deserialized
+func (k Keeper) ConsumeBlsPubKeyGas(ctx sdk.Context, numKeys int) {
+	ctx.GasMeter().ConsumeGas(k.GetParams(ctx).BlsPubKeyGas*uint64(numKeys), "bls pubkey deserialization")
+}
+
+// ConsumeBlsVerifyGas charges the gas for verifying a signature over numMsgs messages, each against its own public key.
+// It is charged before verifying so that running out of gas aborts the tx before the curve math is done
+func (k Keeper) ConsumeBlsVerifyGas(ctx sdk.Context, numMsgs int) {
+	params := k.GetParams(ctx)
+	ctx.GasMeter().ConsumeGas(params.BlsPubKeyGas*uint64(numMsgs), "bls pubkey deserialization")
+	ctx.GasMeter().ConsumeGas(params.BlsPairingGas*uint64(numMsgs+1), "bls pairing")
+}
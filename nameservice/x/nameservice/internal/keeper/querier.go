@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"fmt"
+
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice/internal/types"
 
@@ -10,15 +12,35 @@ import (
 
 // query endpoints supported by the nameservice Querier
 const (
-	QueryOrders = "orders"
+	QueryResolve  = "resolve"
+	QueryWhois    = "whois"
+	QueryNames    = "names"
+	QueryAuction  = "auction"
+	QueryOrder    = "order"
+	QueryOrders   = "orders"
+	QueryMerchant = "merchant"
+	QueryCustomer = "customer"
+	QueryParams   = "params"
 )
 
 // NewQuerier is the module level router for state queries
 func NewQuerier(keeper Keeper) sdk.Querier {
 	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
 		switch path[0] {
+		case QueryResolve:
+			return queryResolve(ctx, path[1:], req, keeper)
+		case QueryWhois:
+			return queryWhois(ctx, path[1:], req, keeper)
+		case QueryNames:
+			return queryNames(ctx, req, keeper)
+		case QueryAuction:
+			return queryAuction(ctx, path[1:], req, keeper)
+		case QueryOrder:
+			return queryOrder(ctx, path[1:], req, keeper)
 		case QueryOrders:
 			return queryOrders(ctx, path[1:], req, keeper)
+		case QueryParams:
+			return queryParams(ctx, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown nameservice query endpoint")
 		}
@@ -26,26 +48,190 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 }
 
 // nolint: unparam
+func queryResolve(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	if len(path) != 1 {
+		return nil, sdk.ErrUnknownRequest("resolve query requires a name")
+	}
+	value := k.ResolveName(ctx, path[0])
+	if value == "" {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("could not resolve name %s", path[0]))
+	}
+
+	res, err := codec.MarshalJSONIndent(k.cdc, types.QueryResResolve{Value: value})
+	if err != nil {
+		panic("could not marshal resolve query result to JSON")
+	}
+
+	return res, nil
+}
+
+// queryWhois returns the Whois of a name. A name nobody owns has no owner and the min name price
+// nolint: unparam
+func queryWhois(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	if len(path) != 1 {
+		return nil, sdk.ErrUnknownRequest("whois query requires a name")
+	}
+
+	res, err := codec.MarshalJSONIndent(k.cdc, k.GetWhois(ctx, path[0]))
+	if err != nil {
+		panic("could not marshal whois query result to JSON")
+	}
+
+	return res, nil
+}
+
+// queryAuction returns the open auction for a name, with the sealed bids committed to it
+// nolint: unparam
+func queryAuction(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	if len(path) != 1 {
+		return nil, sdk.ErrUnknownRequest("auction query requires a name")
+	}
+	auction, found := k.GetAuction(ctx, path[0])
+	if !found {
+		return nil, types.ErrAuctionNotFound(types.DefaultCodespace, path[0])
+	}
+
+	res, err := codec.MarshalJSONIndent(k.cdc, auction)
+	if err != nil {
+		panic("could not marshal auction query result to JSON")
+	}
+
+	return res, nil
+}
+
+// queryNames returns a page of every owned name, paginated by the start key and limit in the query data
+func queryNames(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var params types.QueryNamesParams
+	if len(req.Data) != 0 {
+		if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("failed to parse params: %s", err))
+		}
+	}
+	if params.Limit < 0 || params.Limit > types.MaxNamesLimit {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("limit must be between 0 and %d", types.MaxNamesLimit))
+	}
+	if params.Limit == 0 {
+		params.Limit = types.DefaultNamesLimit
+	}
+
+	// Start the page at the start key and stop one name past the limit, which becomes the next key
+	itr := ctx.KVStore(k.storeKey).Iterator(types.WhoisKey(params.StartKey), sdk.PrefixEndBytes(types.WhoisPrefix))
+	defer itr.Close()
+
+	res := types.QueryResNames{Names: []string{}, Total: k.GetNameCount(ctx)}
+	for ; itr.Valid(); itr.Next() {
+		name := types.NameFromWhoisKey(itr.Key())
+		if len(res.Names) == params.Limit {
+			res.NextKey = name
+			break
+		}
+		res.Names = append(res.Names, name)
+	}
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, res)
+	if err != nil {
+		panic("could not marshal names query result to JSON")
+	}
+
+	return bz, nil
+}
+
+// nolint: unparam
+func queryOrder(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	if len(path) != 1 {
+		return nil, sdk.ErrUnknownRequest("order query requires a channel ID")
+	}
+	if !k.IsEscrowPresent(ctx, path[0]) {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("could not find order %s", path[0]))
+	}
+
+	res, err := codec.MarshalJSONIndent(k.cdc, k.GetEscrow(ctx, path[0]))
+	if err != nil {
+		panic("could not marshal order query result to JSON")
+	}
+
+	return res, nil
+}
+
+// queryOrders returns a page of every order, or of the orders of the merchant or customer in the path,
+// filtered by the status and paginated by the start key and limit in the query data
 func queryOrders(ctx sdk.Context, path []string, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
-	// var escrows [][]byte
-	var escrows []types.Escrow
-	itr := k.GetAllEscrows(ctx)
+	var params types.QueryOrdersParams
+	if len(req.Data) != 0 {
+		if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("failed to parse params: %s", err))
+		}
+	}
+	if params.Status != "" && !types.IsValidStatus(params.Status) {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown order status %s", params.Status))
+	}
+	if params.Limit < 0 || params.Limit > types.MaxOrdersLimit {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("limit must be between 0 and %d", types.MaxOrdersLimit))
+	}
+	if params.Limit == 0 {
+		params.Limit = types.DefaultOrdersLimit
+	}
+
+	// Every prefix is followed by the channel ID, so all orders queries are sorted by channel ID
+	var prefix []byte
+	switch {
+	case len(path) == 0 && params.Status == "":
+		prefix = types.EscrowPrefix
+	case len(path) == 0:
+		prefix = types.StatusIndexKey(params.Status)
+	case len(path) == 2 && (path[0] == QueryMerchant || path[0] == QueryCustomer):
+		addr, err := sdk.AccAddressFromBech32(path[1])
+		if err != nil {
+			return nil, sdk.ErrInvalidAddress(path[1])
+		}
+		if path[0] == QueryMerchant {
+			prefix = types.MerchantIndexKey(addr)
+		} else {
+			prefix = types.CustomerIndexKey(addr)
+		}
+	default:
+		return nil, sdk.ErrUnknownRequest("unknown orders query")
+	}
+	// The status index only holds escrows with that status
+	filterStatus := params.Status != "" && len(path) != 0
+	countKey := prefix
+	if filterStatus {
+		countKey = append(append([]byte{}, prefix...), []byte(params.Status)...)
+	}
+
+	// Start the page at the start key and stop one order past the limit, which becomes the next key
+	start := append(append([]byte{}, prefix...), []byte(params.StartKey)...)
+	itr := ctx.KVStore(k.storeKey).Iterator(start, sdk.PrefixEndBytes(prefix))
 	defer itr.Close()
 
+	res := types.QueryResOrders{Orders: []types.Escrow{}, Total: k.GetOrderCount(ctx, countKey)}
 	for ; itr.Valid(); itr.Next() {
-		// key := itr.Key()
-		escrowBinary := itr.Value()
-		// escrows[i] = escrowBinary
-		var escrow types.Escrow
-		k.cdc.MustUnmarshalBinaryBare(escrowBinary, &escrow)
+		channelID := string(itr.Key()[len(prefix):])
 
-		escrows = append(escrows, escrow)
+		escrow := k.GetEscrow(ctx, channelID)
+		if filterStatus && escrow.Status() != params.Status {
+			continue
+		}
+		if len(res.Orders) == params.Limit {
+			res.NextKey = channelID
+			break
+		}
+		res.Orders = append(res.Orders, escrow)
 	}
-	// return k.cdc.MustMarshalBinaryBare(escrows), nil
-	res, err := codec.MarshalJSONIndent(k.cdc, escrows)
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, res)
 	if err != nil {
 		panic("could not marshal orders query result to JSON")
 	}
 
+	return bz, nil
+}
+
+func queryParams(ctx sdk.Context, k Keeper) ([]byte, sdk.Error) {
+	res, err := codec.MarshalJSONIndent(k.cdc, k.GetParams(ctx))
+	if err != nil {
+		panic("could not marshal params query result to JSON")
+	}
+
 	return res, nil
 }
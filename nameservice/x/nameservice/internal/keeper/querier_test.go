@@ -0,0 +1,232 @@
+package keeper
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice/internal/types"
+)
+
+// setTestEscrow stores an escrow of merchant with the status given and returns its channel ID
+func setTestEscrow(ctx sdk.Context, k Keeper, merchant, customer sdk.AccAddress, sequence uint64, status string) string {
+	channelID := types.NewChannelID(merchant, []byte("token"), sequence)
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10))
+	escrow := types.Escrow{
+		ChannelID: channelID,
+		Merchant:  merchant,
+		Amount:    deposit,
+		Deposit:   deposit,
+	}
+	if status != types.StatusOpen {
+		escrow.Customer = customer
+		escrow.Amount = deposit.Add(deposit)
+		escrow.Filled = true
+	}
+	if status == types.StatusClosing {
+		balance := types.NewChannelBalance(channelID, 1, deposit, deposit)
+		pending := types.NewPendingClose(balance, customer, ctx.BlockHeight(), ctx.BlockHeader().Time, 10)
+		escrow.Close = &pending
+	}
+	k.SetEscrow(ctx, channelID, escrow)
+	return channelID
+}
+
+func queryTestOrders(t *testing.T, ctx sdk.Context, k Keeper, path []string, params types.QueryOrdersParams) types.QueryResOrders {
+	bz, err := NewQuerier(k)(ctx, append([]string{QueryOrders}, path...), abci.RequestQuery{Data: k.cdc.MustMarshalJSON(params)})
+	require.Nil(t, err)
+
+	var res types.QueryResOrders
+	k.cdc.MustUnmarshalJSON(bz, &res)
+	return res
+}
+
+func orderChannelIDs(res types.QueryResOrders) []string {
+	channelIDs := make([]string, len(res.Orders))
+	for i, order := range res.Orders {
+		channelIDs[i] = order.ChannelID
+	}
+	return channelIDs
+}
+
+func TestQueryOrdersPagination(t *testing.T) {
+	ctx, k := createTestInput(t)
+
+	merchant := sdk.AccAddress(crypto.AddressHash([]byte("merchant")))
+	var channelIDs []string
+	for i := uint64(0); i < 5; i++ {
+		channelIDs = append(channelIDs, setTestEscrow(ctx, k, merchant, nil, i, types.StatusOpen))
+	}
+	sort.Strings(channelIDs)
+
+	res := queryTestOrders(t, ctx, k, nil, types.NewQueryOrdersParams("", "", 2))
+	require.Equal(t, channelIDs[:2], orderChannelIDs(res))
+	require.Equal(t, channelIDs[2], res.NextKey)
+	require.Equal(t, uint64(5), res.Total)
+
+	res = queryTestOrders(t, ctx, k, nil, types.NewQueryOrdersParams("", res.NextKey, 2))
+	require.Equal(t, channelIDs[2:4], orderChannelIDs(res))
+	require.Equal(t, channelIDs[4], res.NextKey)
+
+	// The last page has no next key
+	res = queryTestOrders(t, ctx, k, nil, types.NewQueryOrdersParams("", res.NextKey, 2))
+	require.Equal(t, channelIDs[4:], orderChannelIDs(res))
+	require.Empty(t, res.NextKey)
+	require.Equal(t, uint64(5), res.Total)
+
+	// A start key that is not a channel ID starts at the next channel ID after it
+	res = queryTestOrders(t, ctx, k, nil, types.NewQueryOrdersParams("", channelIDs[1]+"0", 2))
+	require.Equal(t, channelIDs[2:4], orderChannelIDs(res))
+
+	// A start key past every channel ID returns an empty page
+	res = queryTestOrders(t, ctx, k, nil, types.NewQueryOrdersParams("", "z", 2))
+	require.Empty(t, res.Orders)
+	require.Empty(t, res.NextKey)
+	require.Equal(t, uint64(5), res.Total)
+
+	// Zero uses the default limit
+	res = queryTestOrders(t, ctx, k, []string{QueryMerchant, merchant.String()}, types.NewQueryOrdersParams("", "", 0))
+	require.Equal(t, channelIDs, orderChannelIDs(res))
+	require.Empty(t, res.NextKey)
+
+	_, err := NewQuerier(k)(ctx, []string{QueryOrders}, abci.RequestQuery{
+		Data: k.cdc.MustMarshalJSON(types.NewQueryOrdersParams("", "", types.MaxOrdersLimit+1)),
+	})
+	require.NotNil(t, err)
+}
+
+func TestQueryOrdersStatusFilter(t *testing.T) {
+	ctx, k := createTestInput(t)
+
+	merchantA := sdk.AccAddress(crypto.AddressHash([]byte("merchantA")))
+	merchantB := sdk.AccAddress(crypto.AddressHash([]byte("merchantB")))
+	customer := sdk.AccAddress(crypto.AddressHash([]byte("customer")))
+
+	openA := setTestEscrow(ctx, k, merchantA, customer, 0, types.StatusOpen)
+	setTestEscrow(ctx, k, merchantA, customer, 1, types.StatusOpen)
+	setTestEscrow(ctx, k, merchantA, customer, 2, types.StatusFilled)
+	setTestEscrow(ctx, k, merchantA, customer, 3, types.StatusFilled)
+	openB := setTestEscrow(ctx, k, merchantB, customer, 0, types.StatusOpen)
+	closingB := setTestEscrow(ctx, k, merchantB, customer, 1, types.StatusClosing)
+
+	cases := []struct {
+		path   []string
+		status string
+		total  uint64
+	}{
+		{nil, "", 6},
+		{nil, types.StatusOpen, 3},
+		{nil, types.StatusFilled, 2},
+		{nil, types.StatusClosing, 1},
+		{[]string{QueryMerchant, merchantA.String()}, "", 4},
+		{[]string{QueryMerchant, merchantA.String()}, types.StatusFilled, 2},
+		{[]string{QueryMerchant, merchantA.String()}, types.StatusClosing, 0},
+		{[]string{QueryMerchant, merchantB.String()}, types.StatusOpen, 1},
+		{[]string{QueryCustomer, customer.String()}, "", 3},
+		{[]string{QueryCustomer, customer.String()}, types.StatusOpen, 0},
+		{[]string{QueryCustomer, customer.String()}, types.StatusClosing, 1},
+	}
+
+	for _, tc := range cases {
+		res := queryTestOrders(t, ctx, k, tc.path, types.NewQueryOrdersParams(tc.status, "", 0))
+		require.Equal(t, tc.total, res.Total, "%v %s", tc.path, tc.status)
+		require.Len(t, res.Orders, int(tc.total), "%v %s", tc.path, tc.status)
+		for _, order := range res.Orders {
+			if tc.status != "" {
+				require.Equal(t, tc.status, order.Status())
+			}
+		}
+	}
+
+	// A filtered page stops at the limit and continues from the next key
+	res := queryTestOrders(t, ctx, k, []string{QueryMerchant, merchantA.String()}, types.NewQueryOrdersParams(types.StatusFilled, "", 1))
+	require.Len(t, res.Orders, 1)
+	require.NotEmpty(t, res.NextKey)
+	res = queryTestOrders(t, ctx, k, []string{QueryMerchant, merchantA.String()}, types.NewQueryOrdersParams(types.StatusFilled, res.NextKey, 1))
+	require.Len(t, res.Orders, 1)
+	require.Equal(t, types.StatusFilled, res.Orders[0].Status())
+	require.Empty(t, res.NextKey)
+
+	// The counts follow escrows as they change status and are deleted
+	escrow := k.GetEscrow(ctx, openA)
+	escrow.Customer = customer
+	escrow.Filled = true
+	k.SetEscrow(ctx, openA, escrow)
+	k.DeleteEscrow(ctx, openB)
+	k.DeleteEscrow(ctx, closingB)
+
+	require.Equal(t, uint64(4), k.GetOrderCount(ctx, types.EscrowPrefix))
+	require.Equal(t, uint64(1), k.GetOrderCount(ctx, types.StatusIndexKey(types.StatusOpen)))
+	require.Equal(t, uint64(3), k.GetOrderCount(ctx, types.StatusIndexKey(types.StatusFilled)))
+	require.Equal(t, uint64(0), k.GetOrderCount(ctx, types.StatusIndexKey(types.StatusClosing)))
+	require.Equal(t, uint64(0), k.GetOrderCount(ctx, types.MerchantIndexKey(merchantB)))
+	require.Equal(t, uint64(3), k.GetOrderCount(ctx, types.CustomerIndexKey(customer)))
+
+	_, err := NewQuerier(k)(ctx, []string{QueryOrders}, abci.RequestQuery{
+		Data: k.cdc.MustMarshalJSON(types.NewQueryOrdersParams("unknown", "", 0)),
+	})
+	require.NotNil(t, err)
+}
+
+func queryTestNames(t *testing.T, ctx sdk.Context, k Keeper, params types.QueryNamesParams) types.QueryResNames {
+	bz, err := NewQuerier(k)(ctx, []string{QueryNames}, abci.RequestQuery{Data: k.cdc.MustMarshalJSON(params)})
+	require.Nil(t, err)
+
+	var res types.QueryResNames
+	k.cdc.MustUnmarshalJSON(bz, &res)
+	return res
+}
+
+func TestQueryNamesPagination(t *testing.T) {
+	ctx, k := createTestInput(t)
+
+	// A store without names returns an empty page
+	res := queryTestNames(t, ctx, k, types.NewQueryNamesParams("", 0))
+	require.Empty(t, res.Names)
+	require.Empty(t, res.NextKey)
+	require.Equal(t, uint64(0), res.Total)
+
+	owner := sdk.AccAddress(crypto.AddressHash([]byte("owner")))
+	names := []string{"alice", "bob", "carol", "dave", "erin"}
+	for _, name := range names {
+		k.SetOwner(ctx, name, owner)
+	}
+
+	res = queryTestNames(t, ctx, k, types.NewQueryNamesParams("", 2))
+	require.Equal(t, names[:2], res.Names)
+	require.Equal(t, "carol", res.NextKey)
+	require.Equal(t, uint64(5), res.Total)
+
+	// The last page has no next key
+	res = queryTestNames(t, ctx, k, types.NewQueryNamesParams("dave", 2))
+	require.Equal(t, names[3:], res.Names)
+	require.Empty(t, res.NextKey)
+	require.Equal(t, uint64(5), res.Total)
+
+	// A start key that is not an owned name starts at the next name after it
+	res = queryTestNames(t, ctx, k, types.NewQueryNamesParams("bobby", 2))
+	require.Equal(t, names[2:4], res.Names)
+	require.Equal(t, "erin", res.NextKey)
+
+	// A start key past every name returns an empty page
+	res = queryTestNames(t, ctx, k, types.NewQueryNamesParams("zed", 2))
+	require.Empty(t, res.Names)
+	require.Empty(t, res.NextKey)
+	require.Equal(t, uint64(5), res.Total)
+
+	// The count follows names as they are released, and updating an owned name does not count it again
+	k.SetName(ctx, "alice", "value")
+	k.DeleteWhois(ctx, "bob")
+	k.DeleteWhois(ctx, "nobody")
+	require.Equal(t, uint64(4), k.GetNameCount(ctx))
+	require.Equal(t, uint64(4), queryTestNames(t, ctx, k, types.NewQueryNamesParams("", 0)).Total)
+
+	_, err := NewQuerier(k)(ctx, []string{QueryNames}, abci.RequestQuery{
+		Data: k.cdc.MustMarshalJSON(types.NewQueryNamesParams("", types.MaxNamesLimit+1)),
+	})
+	require.NotNil(t, err)
+}
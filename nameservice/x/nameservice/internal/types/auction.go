@@ -0,0 +1,133 @@
+package types
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SealedBid is a bidder's commitment to a bid in a name auction. The amount stays hidden behind
+// the bid hash until the bidder reveals it, and the deposit covers any amount up to it
+type SealedBid struct {
+	Bidder   sdk.AccAddress `json:"bidder"`
+	BidHash  []byte         `json:"bid_hash"`
+	Deposit  sdk.Coins      `json:"deposit"`
+	Revealed bool           `json:"revealed"`
+	// Set once the bid is revealed
+	Amount sdk.Coins `json:"amount"`
+}
+
+// Auction is a sealed-bid second-price auction for a name. Bids are committed until CommitEndHeight and
+// revealed until RevealEndHeight, when the highest revealed bid wins the name at the second highest price
+type Auction struct {
+	Name            string      `json:"name"`
+	CommitEndHeight int64       `json:"commit_end_height"`
+	RevealEndHeight int64       `json:"reveal_end_height"`
+	Bids            []SealedBid `json:"bids"`
+}
+
+// NewAuction returns a new Auction for a name started at height
+func NewAuction(name string, height int64, commitPeriod int64, revealPeriod int64) Auction {
+	return Auction{
+		Name:            name,
+		CommitEndHeight: height + commitPeriod,
+		RevealEndHeight: height + commitPeriod + revealPeriod,
+		Bids:            []SealedBid{},
+	}
+}
+
+// implement fmt.Stringer
+func (a Auction) String() string {
+	var revealed int
+	for _, bid := range a.Bids {
+		if bid.Revealed {
+			revealed++
+		}
+	}
+	return strings.TrimSpace(fmt.Sprintf(`Name: %s
+Commit End Height: %d
+Reveal End Height: %d
+Bids: %d
+Revealed Bids: %d`, a.Name, a.CommitEndHeight, a.RevealEndHeight, len(a.Bids), revealed))
+}
+
+// IsCommitPhase returns whether the auction takes new bids at height
+func (a Auction) IsCommitPhase(height int64) bool {
+	return height <= a.CommitEndHeight
+}
+
+// IsRevealPhase returns whether committed bids can be revealed at height
+func (a Auction) IsRevealPhase(height int64) bool {
+	return height > a.CommitEndHeight && height <= a.RevealEndHeight
+}
+
+// BidIndex returns the index of a bidder's bid, or -1 if they have not bid
+func (a Auction) BidIndex(bidder sdk.AccAddress) int {
+	for i, bid := range a.Bids {
+		if bid.Bidder.Equals(bidder) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Deposits returns the sum of the deposits of every bid
+func (a Auction) Deposits() sdk.Coins {
+	deposits := sdk.NewCoins()
+	for _, bid := range a.Bids {
+		deposits = deposits.Add(bid.Deposit)
+	}
+	return deposits
+}
+
+// Winner returns the index of the highest revealed bid of at least the single denomination reserve price
+// and the price it pays, which is the second highest such bid or the reserve price if there is none.
+// Ties go to the bid committed first. It returns false if no revealed bid meets the reserve price
+func (a Auction) Winner(reserve sdk.Coins) (int, sdk.Coins, bool) {
+	if reserve.Len() != 1 {
+		return -1, nil, false
+	}
+	denom := reserve[0].Denom
+
+	winner := -1
+	price := reserve
+	for i, bid := range a.Bids {
+		if !bid.Revealed || bid.Amount.Len() != 1 || bid.Amount[0].Denom != denom || !bid.Amount.IsAllGTE(reserve) {
+			continue
+		}
+		switch {
+		case winner < 0:
+			winner = i
+		case bid.Amount[0].Amount.GT(a.Bids[winner].Amount[0].Amount):
+			price = a.Bids[winner].Amount
+			winner = i
+		case bid.Amount[0].Amount.GT(price[0].Amount):
+			price = bid.Amount
+		}
+	}
+	return winner, price, winner >= 0
+}
+
+// BidCommitment is what a bid hash commits to. Binding the bidder and the name keeps a commitment
+// from being copied by another bidder or into another auction, and the salt keeps small bids from
+// being guessed
+type BidCommitment struct {
+	Name   string         `json:"name"`
+	Bidder sdk.AccAddress `json:"bidder"`
+	Amount sdk.Coins      `json:"amount"`
+	Salt   []byte         `json:"salt"`
+}
+
+// NewBidHash returns the hash a bidder commits to for a bid of amount on a name
+func NewBidHash(name string, bidder sdk.AccAddress, amount sdk.Coins, salt []byte) []byte {
+	commitment := BidCommitment{
+		Name:   name,
+		Bidder: bidder,
+		Amount: amount,
+		Salt:   salt,
+	}
+	hash := sha256.Sum256(sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(commitment)))
+	return hash[:]
+}
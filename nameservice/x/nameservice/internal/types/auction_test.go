@@ -0,0 +1,60 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func revealedBid(bidder string, amount int64) SealedBid {
+	return SealedBid{
+		Bidder:   sdk.AccAddress([]byte(bidder)),
+		Deposit:  sdk.NewCoins(sdk.NewInt64Coin("nametoken", amount)),
+		Revealed: true,
+		Amount:   sdk.NewCoins(sdk.NewInt64Coin("nametoken", amount)),
+	}
+}
+
+func TestAuctionWinner(t *testing.T) {
+	reserve := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5))
+	unrevealed := revealedBid("hidden", 100)
+	unrevealed.Revealed = false
+	otherDenom := revealedBid("other", 100)
+	otherDenom.Amount = sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+
+	cases := []struct {
+		name   string
+		bids   []SealedBid
+		won    bool
+		winner int
+		price  int64
+	}{
+		{"no bids", nil, false, -1, 0},
+		{"single bid pays the reserve", []SealedBid{revealedBid("a", 10)}, true, 0, 5},
+		{"highest bid pays the second highest", []SealedBid{revealedBid("a", 10), revealedBid("b", 30), revealedBid("c", 20)}, true, 1, 20},
+		{"second highest bid after the winner", []SealedBid{revealedBid("a", 30), revealedBid("b", 10), revealedBid("c", 20)}, true, 0, 20},
+		{"tie goes to the first bid at the tied price", []SealedBid{revealedBid("a", 30), revealedBid("b", 30)}, true, 0, 30},
+		{"bids below the reserve are ignored", []SealedBid{revealedBid("a", 4), revealedBid("b", 6)}, true, 1, 5},
+		{"no bid meets the reserve", []SealedBid{revealedBid("a", 4)}, false, -1, 0},
+		{"unrevealed bids are ignored", []SealedBid{unrevealed, revealedBid("a", 10)}, true, 1, 5},
+		{"bids in another denomination are ignored", []SealedBid{otherDenom, revealedBid("a", 10)}, true, 1, 5},
+	}
+
+	for _, tc := range cases {
+		auction := NewAuction("name", 0, 10, 10)
+		auction.Bids = tc.bids
+		winner, price, won := auction.Winner(reserve)
+		require.Equal(t, tc.won, won, tc.name)
+		require.Equal(t, tc.winner, winner, tc.name)
+		if tc.won {
+			require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("nametoken", tc.price)), price, tc.name)
+		}
+	}
+
+	// A reserve price in more than one denomination cannot be compared against
+	auction := NewAuction("name", 0, 10, 10)
+	auction.Bids = []SealedBid{revealedBid("a", 10)}
+	_, _, won := auction.Winner(reserve.Add(sdk.NewCoins(sdk.NewInt64Coin("stake", 1))))
+	require.False(t, won)
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"github.com/phoreproject/bls/g2pubs"
+)
+
+// Bls12381PubKey is a serialized Bls12-381 public key
+type Bls12381PubKey = [96]byte
+
+// Bls12381Signature is a serialized Bls12-381 signature
+type Bls12381Signature = [48]byte
+
+// ValidateSignature verifies a signature against a message and a public key
+func ValidateSignature(bls13PubKey Bls12381PubKey, signature Bls12381Signature, message []byte) bool {
+	sig, err := g2pubs.DeserializeSignature(signature)
+	if err != nil {
+		return false
+	}
+
+	pubKey, err := g2pubs.DeserializePublicKey(bls13PubKey)
+	if err != nil {
+		return false
+	}
+
+	return g2pubs.Verify(message, pubKey, sig)
+}
+
+// ValidatePubKey verifies a public key deserializes to a point on the Bls12-381 curve
+func ValidatePubKey(bls13PubKey Bls12381PubKey) bool {
+	_, err := g2pubs.DeserializePublicKey(bls13PubKey)
+	if err != nil {
+		return false
+	}
+	return true
+}
+
+// ValidateAggregateSignature verifies an aggregate signature against messages each signed by the matching public key.
+// The messages must be distinct
+func ValidateAggregateSignature(bls13PubKeys []Bls12381PubKey, signature Bls12381Signature, messages [][]byte) bool {
+	sig, err := g2pubs.DeserializeSignature(signature)
+	if err != nil {
+		return false
+	}
+
+	pubKeys := make([]*g2pubs.PublicKey, len(bls13PubKeys))
+	for i, bls13PubKey := range bls13PubKeys {
+		pubKey, err := g2pubs.DeserializePublicKey(bls13PubKey)
+		if err != nil {
+			return false
+		}
+		pubKeys[i] = pubKey
+	}
+
+	return sig.VerifyAggregate(pubKeys, messages)
+}
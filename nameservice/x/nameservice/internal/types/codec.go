@@ -16,6 +16,15 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgSetName{}, "nameservice/SetName", nil)
 	cdc.RegisterConcrete(MsgBuyName{}, "nameservice/BuyName", nil)
 	cdc.RegisterConcrete(MsgDeleteName{}, "nameservice/DeleteName", nil)
+	cdc.RegisterConcrete(MsgRenewName{}, "nameservice/RenewName", nil)
+	cdc.RegisterConcrete(MsgCommitBid{}, "nameservice/CommitBid", nil)
+	cdc.RegisterConcrete(MsgRevealBid{}, "nameservice/RevealBid", nil)
 	cdc.RegisterConcrete(MsgCreateOrder{}, "escrow/CreateOrder", nil)
 	cdc.RegisterConcrete(MsgFillOrder{}, "escrow/FillOrder", nil)
+	cdc.RegisterConcrete(MsgClaimOrder{}, "escrow/ClaimOrder", nil)
+	cdc.RegisterConcrete(MsgBatchClaim{}, "escrow/BatchClaim", nil)
+	cdc.RegisterConcrete(MsgDisputeClose{}, "escrow/DisputeClose", nil)
+	cdc.RegisterConcrete(MsgCustomerClose{}, "escrow/CustomerClose", nil)
+	cdc.RegisterConcrete(MsgRevokeClose{}, "escrow/RevokeClose", nil)
+	cdc.RegisterConcrete(MsgMutualClose{}, "escrow/MutualClose", nil)
 }
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -8,10 +10,124 @@ import (
 const (
 	DefaultCodespace sdk.CodespaceType = ModuleName
 
-	CodeNameDoesNotExist sdk.CodeType = 101
+	CodeNameDoesNotExist     sdk.CodeType = 101
+	CodeOrderNotFound        sdk.CodeType = 102
+	CodeOrderAlreadyFilled   sdk.CodeType = 103
+	CodeOrderNotFilled       sdk.CodeType = 104
+	CodeOrderClosing         sdk.CodeType = 105
+	CodeOrderNotClosing      sdk.CodeType = 106
+	CodeDenomMismatch        sdk.CodeType = 107
+	CodeInvalidEscrowAmount  sdk.CodeType = 108
+	CodeBalanceMismatch      sdk.CodeType = 109
+	CodeInvalidBlsPubKey     sdk.CodeType = 110
+	CodeInvalidBlsSignature  sdk.CodeType = 111
+	CodeStaleNonce           sdk.CodeType = 112
+	CodeDisputePeriodExpired sdk.CodeType = 113
+	CodeNameExpired          sdk.CodeType = 114
+	CodeAuctionNotFound      sdk.CodeType = 115
+	CodeInvalidAuctionPhase  sdk.CodeType = 116
+	CodeBidAlreadyCommitted  sdk.CodeType = 117
+	CodeBidNotFound          sdk.CodeType = 118
+	CodeInvalidBidReveal     sdk.CodeType = 119
+	CodeAuctionRequired      sdk.CodeType = 120
 )
 
 // ErrNameDoesNotExist is the error for name not existing
 func ErrNameDoesNotExist(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeNameDoesNotExist, "Name does not exist")
 }
+
+// ErrOrderNotFound is the error for an order with no escrow under its channel ID
+func ErrOrderNotFound(codespace sdk.CodespaceType, channelID string) sdk.Error {
+	return sdk.NewError(codespace, CodeOrderNotFound, fmt.Sprintf("Order %s does not exist. Channel Escrow not found", channelID))
+}
+
+// ErrOrderAlreadyFilled is the error for filling an order a customer has already filled
+func ErrOrderAlreadyFilled(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeOrderAlreadyFilled, "Order has already been filled")
+}
+
+// ErrOrderNotFilled is the error for closing an order no customer has filled yet
+func ErrOrderNotFilled(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeOrderNotFilled, "Order has not been filled")
+}
+
+// ErrOrderClosing is the error for closing an order that is already waiting out its dispute period
+func ErrOrderClosing(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeOrderClosing, "Order is already closing")
+}
+
+// ErrOrderNotClosing is the error for disputing or revoking an order nobody has closed
+func ErrOrderNotClosing(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeOrderNotClosing, "Order is not closing")
+}
+
+// ErrDenomMismatch is the error for coins whose denominations do not match the escrow or the name price
+func ErrDenomMismatch(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeDenomMismatch, msg)
+}
+
+// ErrInvalidEscrowAmount is the error for an escrow amount outside the module params or not matching the order
+func ErrInvalidEscrowAmount(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidEscrowAmount, msg)
+}
+
+// ErrBalanceMismatch is the error for channel balances that do not add up to the escrowed amount
+func ErrBalanceMismatch(codespace sdk.CodespaceType, amount sdk.Coins) sdk.Error {
+	return sdk.NewError(codespace, CodeBalanceMismatch, fmt.Sprintf("Incorrect balances. Must add up to %s", amount))
+}
+
+// ErrInvalidBlsPubKey is the error for bytes that are not a Bls12-381 public key
+func ErrInvalidBlsPubKey(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidBlsPubKey, msg)
+}
+
+// ErrInvalidBlsSignature is the error for a Bls12-381 signature that is malformed or does not verify
+func ErrInvalidBlsSignature(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidBlsSignature, msg)
+}
+
+// ErrStaleNonce is the error for disputing a close with a balance that is not newer than the pending one
+func ErrStaleNonce(codespace sdk.CodespaceType, nonce uint64) sdk.Error {
+	return sdk.NewError(codespace, CodeStaleNonce, fmt.Sprintf("Disputed balance must be newer than nonce %d", nonce))
+}
+
+// ErrDisputePeriodExpired is the error for disputing or revoking a close whose dispute period has ended
+func ErrDisputePeriodExpired(codespace sdk.CodespaceType, matureHeight int64) sdk.Error {
+	return sdk.NewError(codespace, CodeDisputePeriodExpired, fmt.Sprintf("Dispute period ended at height %d", matureHeight))
+}
+
+// ErrNameExpired is the error for changing the value of a name that has expired and must be renewed first
+func ErrNameExpired(codespace sdk.CodespaceType, expiryHeight int64) sdk.Error {
+	return sdk.NewError(codespace, CodeNameExpired, fmt.Sprintf("Name expired at height %d and must be renewed", expiryHeight))
+}
+
+// ErrAuctionNotFound is the error for revealing a bid on a name that is not being auctioned
+func ErrAuctionNotFound(codespace sdk.CodespaceType, name string) sdk.Error {
+	return sdk.NewError(codespace, CodeAuctionNotFound, fmt.Sprintf("No auction for name %s", name))
+}
+
+// ErrInvalidAuctionPhase is the error for committing or revealing a bid outside of the auction phase that allows it
+func ErrInvalidAuctionPhase(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAuctionPhase, msg)
+}
+
+// ErrBidAlreadyCommitted is the error for committing a second bid in the same auction
+func ErrBidAlreadyCommitted(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeBidAlreadyCommitted, "Bidder has already committed a bid in this auction")
+}
+
+// ErrBidNotFound is the error for revealing a bid that was never committed
+func ErrBidNotFound(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeBidNotFound, "Bidder has not committed a bid in this auction")
+}
+
+// ErrInvalidBidReveal is the error for a revealed bid that does not match its commitment or its deposit
+func ErrInvalidBidReveal(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidBidReveal, msg)
+}
+
+// ErrAuctionRequired is the error for buying a name directly that can only be bought by sealed-bid auction
+func ErrAuctionRequired(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeAuctionRequired, msg)
+}
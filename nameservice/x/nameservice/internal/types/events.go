@@ -0,0 +1,53 @@
+package types
+
+// nameservice module event types
+const (
+	EventTypeSetName    = "set_name"
+	EventTypeBuyName    = "buy_name"
+	EventTypeDeleteName = "delete_name"
+	EventTypeRenewName  = "renew_name"
+	EventTypeExpireName = "expire_name"
+
+	EventTypeCommitBid     = "commit_bid"
+	EventTypeRevealBid     = "reveal_bid"
+	EventTypeSettleAuction = "settle_auction"
+	EventTypeRefundDeposit = "refund_deposit"
+	EventTypeSlashDeposit  = "slash_deposit"
+
+	EventTypeCreateOrder   = "create_order"
+	EventTypeFillOrder     = "fill_order"
+	EventTypeClaim         = "claim"
+	EventTypeDisputeClose  = "dispute_close"
+	EventTypeCustomerClose = "customer_close"
+	EventTypeRevokeClose   = "revoke_close"
+	EventTypeMutualClose   = "mutual_close"
+	EventTypePayout        = "payout"
+
+	AttributeKeyName          = "name"
+	AttributeKeyValue         = "value"
+	AttributeKeyOwner         = "owner"
+	AttributeKeyBuyer         = "buyer"
+	AttributeKeyPreviousOwner = "previous_owner"
+	AttributeKeyPrice         = "price"
+	AttributeKeyFee           = "fee"
+	AttributeKeyExpiryHeight  = "expiry_height"
+	AttributeKeyProceeds      = "proceeds"
+	AttributeKeyProtocolFee   = "protocol_fee"
+
+	AttributeKeyBidder          = "bidder"
+	AttributeKeyDeposit         = "deposit"
+	AttributeKeyWinner          = "winner"
+	AttributeKeyCommitEndHeight = "commit_end_height"
+	AttributeKeyRevealEndHeight = "reveal_end_height"
+
+	AttributeKeyChannelID       = "channel_id"
+	AttributeKeyMerchant        = "merchant"
+	AttributeKeyCustomer        = "customer"
+	AttributeKeyAmount          = "amount"
+	AttributeKeyNonce           = "nonce"
+	AttributeKeyMerchantBalance = "merchant_balance"
+	AttributeKeyCustomerBalance = "customer_balance"
+	AttributeKeyMatureHeight    = "mature_height"
+
+	AttributeValueCategory = ModuleName
+)
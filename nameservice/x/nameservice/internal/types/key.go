@@ -1,9 +1,145 @@
 package types
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 const (
 	// ModuleName is the name of the module
 	ModuleName = "nameservice"
 
 	// StoreKey to be used when creating the KVStore
 	StoreKey = ModuleName
+
+	// EscrowAccountName is the name of the module account holding escrowed coins
+	EscrowAccountName = "escrow"
+
+	// AuctionAccountName is the name of the module account holding the deposits of sealed bids
+	AuctionAccountName = "auction"
+)
+
+// StoreVersion is the version of the key schema below. Stores written before it existed are
+// unprefixed, with names keyed by the raw name and escrows by the merchant's bech32 address.
+// Version 2 added the BLS gas params, version 3 the name and escrow params, version 4 recorded
+// the merchant deposit of every escrow, version 5 name expiry, version 6 name auctions, version 7
+// the resale fee rate, version 8 rebuilt the escrow indexes for escrows written before they existed
+// version 9 counted the escrows under every index and version 10 counted the owned names
+const StoreVersion uint64 = 10
+
+// Every record type lives under its own prefix so that iterators never decode the wrong type
+var (
+	// StoreVersionKey holds the StoreVersion the store has been migrated to
+	StoreVersionKey = []byte{0x00}
+
+	// ClosingQueuePrefix prefixes escrows waiting out their dispute period, ordered by the height they mature at
+	ClosingQueuePrefix = []byte{0x01}
+
+	// ChannelSequencePrefix prefixes the number of channels each merchant has opened
+	ChannelSequencePrefix = []byte{0x02}
+
+	// WhoisPrefix prefixes the Whois of every name
+	WhoisPrefix = []byte{0x03}
+
+	// EscrowPrefix prefixes every escrow by its channel ID
+	EscrowPrefix = []byte{0x04}
+
+	// MerchantIndexPrefix indexes the channel IDs of every escrow by merchant
+	MerchantIndexPrefix = []byte{0x05}
+
+	// CustomerIndexPrefix indexes the channel IDs of every filled escrow by customer
+	CustomerIndexPrefix = []byte{0x06}
+
+	// StatusIndexPrefix indexes the channel IDs of every escrow by status
+	StatusIndexPrefix = []byte{0x07}
+
+	// ExpiryQueuePrefix prefixes every owned name, ordered by the height it expires at
+	ExpiryQueuePrefix = []byte{0x08}
+
+	// AuctionPrefix prefixes every open name auction by its name
+	AuctionPrefix = []byte{0x09}
+
+	// AuctionQueuePrefix prefixes every open name auction, ordered by the height its reveal phase ends at
+	AuctionQueuePrefix = []byte{0x0a}
+
+	// OrderCountPrefix prefixes the number of escrows under every escrow index prefix
+	OrderCountPrefix = []byte{0x0b}
+
+	// NameCountKey holds the number of owned names
+	NameCountKey = []byte{0x0c}
 )
+
+// MerchantIndexKey returns the merchant index prefix of all escrows of a merchant
+func MerchantIndexKey(merchant sdk.AccAddress) []byte {
+	return append(MerchantIndexPrefix, merchant.Bytes()...)
+}
+
+// CustomerIndexKey returns the customer index prefix of all escrows of a customer
+func CustomerIndexKey(customer sdk.AccAddress) []byte {
+	return append(CustomerIndexPrefix, customer.Bytes()...)
+}
+
+// StatusIndexKey returns the status index prefix of all escrows with a status
+func StatusIndexKey(status string) []byte {
+	return append(StatusIndexPrefix, []byte(status)...)
+}
+
+// OrderCountKey returns the key of the number of escrows under an escrow index prefix. The merchant and
+// customer index prefixes followed by a status count the escrows of that merchant or customer with the status
+func OrderCountKey(indexKey []byte) []byte {
+	return append(OrderCountPrefix, indexKey...)
+}
+
+// WhoisKey returns the key of a name's Whois
+func WhoisKey(name string) []byte {
+	return append(WhoisPrefix, []byte(name)...)
+}
+
+// NameFromWhoisKey returns the name a Whois key was built from
+func NameFromWhoisKey(key []byte) string {
+	return string(key[len(WhoisPrefix):])
+}
+
+// EscrowKey returns the key of an escrow
+func EscrowKey(channelID string) []byte {
+	return append(EscrowPrefix, []byte(channelID)...)
+}
+
+// ChannelSequenceKey returns the key of a merchant's channel sequence
+func ChannelSequenceKey(merchant sdk.AccAddress) []byte {
+	return append(ChannelSequencePrefix, merchant.Bytes()...)
+}
+
+// ClosingQueueHeightKey returns the closing queue prefix for all escrows maturing at height
+func ClosingQueueHeightKey(height int64) []byte {
+	return append(ClosingQueuePrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// ClosingQueueKey returns the closing queue key of an escrow maturing at height
+func ClosingQueueKey(height int64, channelID string) []byte {
+	return append(ClosingQueueHeightKey(height), []byte(channelID)...)
+}
+
+// ExpiryQueueHeightKey returns the expiry queue prefix for all names expiring at height
+func ExpiryQueueHeightKey(height int64) []byte {
+	return append(ExpiryQueuePrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// ExpiryQueueKey returns the expiry queue key of a name expiring at height
+func ExpiryQueueKey(height int64, name string) []byte {
+	return append(ExpiryQueueHeightKey(height), []byte(name)...)
+}
+
+// AuctionKey returns the key of a name's auction
+func AuctionKey(name string) []byte {
+	return append(AuctionPrefix, []byte(name)...)
+}
+
+// AuctionQueueHeightKey returns the auction queue prefix for all auctions ending at height
+func AuctionQueueHeightKey(height int64) []byte {
+	return append(AuctionQueuePrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// AuctionQueueKey returns the auction queue key of a name's auction ending at height
+func AuctionQueueKey(height int64, name string) []byte {
+	return append(AuctionQueueHeightKey(height), []byte(name)...)
+}
@@ -1,6 +1,9 @@
 package types
 
 import (
+	"crypto/sha256"
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -80,8 +83,8 @@ func (msg MsgBuyName) ValidateBasic() sdk.Error {
 	if len(msg.Name) == 0 {
 		return sdk.ErrUnknownRequest("Name cannot be empty")
 	}
-	if !msg.Bid.IsAllPositive() {
-		return sdk.ErrInsufficientCoins("Bids must be positive")
+	if msg.Bid.Len() != 1 || !msg.Bid.IsAllPositive() {
+		return sdk.ErrInsufficientCoins("Bids must be a single positive denomination")
 	}
 	return nil
 }
@@ -137,22 +140,166 @@ func (msg MsgDeleteName) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Owner}
 }
 
+// MsgRenewName defines a RenewName message
+type MsgRenewName struct {
+	Name  string         `json:"name"`
+	Owner sdk.AccAddress `json:"owner"`
+}
+
+// NewMsgRenewName is a constructor function for MsgRenewName
+func NewMsgRenewName(name string, owner sdk.AccAddress) MsgRenewName {
+	return MsgRenewName{
+		Name:  name,
+		Owner: owner,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgRenewName) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgRenewName) Type() string { return "renew_name" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgRenewName) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if len(msg.Name) == 0 {
+		return sdk.ErrUnknownRequest("Name cannot be empty")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgRenewName) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgRenewName) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgCommitBid defines a CommitBid message, committing to a sealed bid on a name and depositing
+// enough to cover it. The first bid on a name starts its auction
+type MsgCommitBid struct {
+	Name    string         `json:"name"`
+	Bidder  sdk.AccAddress `json:"bidder"`
+	BidHash []byte         `json:"bid_hash"` // sha256 of the BidCommitment
+	Deposit sdk.Coins      `json:"deposit"`
+}
+
+// NewMsgCommitBid is a constructor function for MsgCommitBid
+func NewMsgCommitBid(name string, bidder sdk.AccAddress, bidHash []byte, deposit sdk.Coins) MsgCommitBid {
+	return MsgCommitBid{
+		Name:    name,
+		Bidder:  bidder,
+		BidHash: bidHash,
+		Deposit: deposit,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgCommitBid) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgCommitBid) Type() string { return "commit_bid" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgCommitBid) ValidateBasic() sdk.Error {
+	if msg.Bidder.Empty() {
+		return sdk.ErrInvalidAddress(msg.Bidder.String())
+	}
+	if len(msg.Name) == 0 {
+		return sdk.ErrUnknownRequest("Name cannot be empty")
+	}
+	if len(msg.BidHash) != sha256.Size {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("Bid hash must be %d bytes", sha256.Size))
+	}
+	if msg.Deposit.Len() != 1 || !msg.Deposit.IsAllPositive() {
+		return sdk.ErrInsufficientCoins("Deposit must be a single positive denomination")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgCommitBid) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgCommitBid) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Bidder}
+}
+
+// MsgRevealBid defines a RevealBid message, opening a sealed bid once its auction stops taking bids
+type MsgRevealBid struct {
+	Name   string         `json:"name"`
+	Bidder sdk.AccAddress `json:"bidder"`
+	Amount sdk.Coins      `json:"amount"`
+	Salt   []byte         `json:"salt"`
+}
+
+// NewMsgRevealBid is a constructor function for MsgRevealBid
+func NewMsgRevealBid(name string, bidder sdk.AccAddress, amount sdk.Coins, salt []byte) MsgRevealBid {
+	return MsgRevealBid{
+		Name:   name,
+		Bidder: bidder,
+		Amount: amount,
+		Salt:   salt,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgRevealBid) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgRevealBid) Type() string { return "reveal_bid" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgRevealBid) ValidateBasic() sdk.Error {
+	if msg.Bidder.Empty() {
+		return sdk.ErrInvalidAddress(msg.Bidder.String())
+	}
+	if len(msg.Name) == 0 {
+		return sdk.ErrUnknownRequest("Name cannot be empty")
+	}
+	if msg.Amount.Len() != 1 || !msg.Amount.IsAllPositive() {
+		return sdk.ErrInsufficientCoins("Bid must be a single positive denomination")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgRevealBid) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgRevealBid) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Bidder}
+}
+
 ///////////////////////////////////
 type MsgCreateOrder struct {
 	Merchant     sdk.AccAddress `json:"merchant"`
-	ChannelState string         `json:"channelState"`
-	ChannelToken string         `json:"channelToken"`
-	Amount       sdk.Coins      `json:"amount"`
+	ChannelState []byte         `json:"channelState"` // 96 byte Bls12-381 public key
+	ChannelToken []byte         `json:"channelToken"`
+	// Bls12-381 signature over the PossessionProof of the Merchant, made with the ChannelState key
+	ProofOfPossession []byte    `json:"proofOfPossession"` // 48 bytes
+	Amount            sdk.Coins `json:"amount"`
 }
 
 // NewMsgCreateOrder is a constructor
-func NewMsgCreateOrder(merchant sdk.AccAddress, channelState string, channelToken string, amount sdk.Coins) MsgCreateOrder {
+func NewMsgCreateOrder(merchant sdk.AccAddress, channelState []byte, channelToken []byte, proofOfPossession []byte, amount sdk.Coins) MsgCreateOrder {
 	// TODO incorporate all the things from rainboltd message
 	return MsgCreateOrder{
-		Merchant:     merchant,
-		ChannelState: channelState,
-		ChannelToken: channelToken,
-		Amount:       amount,
+		Merchant:          merchant,
+		ChannelState:      channelState,
+		ChannelToken:      channelToken,
+		ProofOfPossession: proofOfPossession,
+		Amount:            amount,
 	}
 }
 
@@ -162,17 +309,20 @@ func (msg MsgCreateOrder) Route() string { return RouterKey }
 // Type should return the action
 func (msg MsgCreateOrder) Type() string { return "create_order" }
 
-// ValidateBasic checks that the ChannelState is a valid Bls12381 public key and that Amount > 0
+// ValidateBasic checks that the ChannelState is the length of a Bls12381 public key, the ProofOfPossession is a signature and that Amount > 0
 func (msg MsgCreateOrder) ValidateBasic() sdk.Error {
 	if msg.Merchant.Empty() {
 		return sdk.ErrInvalidAddress(msg.Merchant.String())
 	}
-	// var sigBytes Bls12381PubKey
-	// copy(sigBytes[:], msg.ChannelState)
-	// _, err := g2pubs.DeserializePublicKey(sigBytes)
-	// if err != nil {
-	// 	return sdk.ErrInvalidPubKey(fmt.Sprintf("%s", msg.ChannelState))
-	// }
+	if err := validateBls12381PubKey(msg.ChannelState); err != nil {
+		return err
+	}
+	if len(msg.ChannelToken) == 0 {
+		return sdk.ErrUnknownRequest("ChannelToken cannot be empty")
+	}
+	if len(msg.ProofOfPossession) != len(Bls12381Signature{}) {
+		return ErrInvalidBlsSignature(DefaultCodespace, "ProofOfPossession must be a 48 byte Bls12-381 signature")
+	}
 	if msg.Amount.Empty() {
 		return sdk.ErrInsufficientCoins("Amount must be greater than 0")
 	}
@@ -192,19 +342,18 @@ func (msg MsgCreateOrder) GetSigners() []sdk.AccAddress {
 // MsgFillOrder defines a FillOrder message
 type MsgFillOrder struct {
 	Customer sdk.AccAddress `json:"customer"`
-	// Currently used to lookup the escrow in the KV. Should be obfuscated (and not used as a key)
-	// in the future for privacy
-	Merchant sdk.AccAddress `json:"merchant"`
+	// Used to lookup the escrow in the KV
+	ChannelID string `json:"channelID"`
 	// Used to verify that Merchant and Customer are talking on the same channel
 	WalletCommit []byte    `json:"walletCommit"` // 96 bytes
 	Amount       sdk.Coins `json:"amount"`
 }
 
 // NewMsgCreateOrder is a constructor
-func NewMsgFillOrder(merchant sdk.AccAddress, customer sdk.AccAddress, walletCommit []byte, amount sdk.Coins) MsgFillOrder {
+func NewMsgFillOrder(channelID string, customer sdk.AccAddress, walletCommit []byte, amount sdk.Coins) MsgFillOrder {
 	// TODO incorporate all the things from rainboltd message
 	return MsgFillOrder{
-		Merchant:     merchant,
+		ChannelID:    channelID,
 		Customer:     customer,
 		WalletCommit: walletCommit,
 		Amount:       amount,
@@ -217,21 +366,18 @@ func (msg MsgFillOrder) Route() string { return RouterKey }
 // Type should return the action
 func (msg MsgFillOrder) Type() string { return "fill_order" }
 
-// ValidateBasic checks that the ChannelState is a valid Bls12 public key, Amount > 0, and addresses are not empty
+// ValidateBasic checks that the WalletCommit is the length of a Bls12381 public key, Amount > 0, and addresses are not empty
 func (msg MsgFillOrder) ValidateBasic() sdk.Error {
 	if msg.Customer.Empty() {
 		return sdk.ErrInvalidAddress(msg.Customer.String())
 	}
 
-	if msg.Merchant.Empty() {
-		return sdk.ErrInvalidAddress(msg.Merchant.String())
+	if len(msg.ChannelID) == 0 {
+		return sdk.ErrUnknownRequest("ChannelID cannot be empty")
+	}
+	if err := validateBls12381PubKey(msg.WalletCommit); err != nil {
+		return err
 	}
-	// var sigBytes Bls12381PubKey
-	// copy(sigBytes[:], msg.ChannelState)
-	// _, err := g2pubs.DeserializePublicKey(sigBytes)
-	// if err != nil {
-	// 	return sdk.ErrInvalidPubKey(fmt.Sprintf("%s", msg.ChannelState))
-	// }
 	if msg.Amount.Empty() {
 		return sdk.ErrInsufficientCoins("Amount must be greater than 0")
 	}
@@ -250,4 +396,406 @@ func (msg MsgFillOrder) GetSigners() []sdk.AccAddress {
 
 // MsgClaimOrder defines a ClaimOrder message
 type MsgClaimOrder struct {
+	Merchant sdk.AccAddress `json:"merchant"`
+	// Used to lookup the escrow in the KV
+	ChannelID       string    `json:"channelID"`
+	Nonce           uint64    `json:"nonce"`
+	MerchantBalance sdk.Coins `json:"merchantBalance"`
+	CustomerBalance sdk.Coins `json:"customerBalance"`
+	// Bls12-381 signature over the ChannelBalance, made by the customer with the WalletCommit key
+	// when the channel moved to this balance
+	Signature []byte `json:"signature"` // 48 bytes
+}
+
+// NewMsgClaimOrder is a constructor
+func NewMsgClaimOrder(merchant sdk.AccAddress, channelID string, nonce uint64, merchantBalance sdk.Coins, customerBalance sdk.Coins, signature []byte) MsgClaimOrder {
+	return MsgClaimOrder{
+		Merchant:        merchant,
+		ChannelID:       channelID,
+		Nonce:           nonce,
+		MerchantBalance: merchantBalance,
+		CustomerBalance: customerBalance,
+		Signature:       signature,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgClaimOrder) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgClaimOrder) Type() string { return "claim_order" }
+
+// ValidateBasic checks that the balances are valid, the signature is 48 bytes and the merchant is not empty
+func (msg MsgClaimOrder) ValidateBasic() sdk.Error {
+	if msg.Merchant.Empty() {
+		return sdk.ErrInvalidAddress(msg.Merchant.String())
+	}
+	if len(msg.ChannelID) == 0 {
+		return sdk.ErrUnknownRequest("ChannelID cannot be empty")
+	}
+	if !msg.MerchantBalance.IsValid() || !msg.CustomerBalance.IsValid() {
+		return sdk.ErrInvalidCoins("Balances must be valid coins")
+	}
+	if msg.MerchantBalance.Empty() && msg.CustomerBalance.Empty() {
+		return sdk.ErrInsufficientCoins("Balances cannot both be empty")
+	}
+	if len(msg.Signature) != len(Bls12381Signature{}) {
+		return ErrInvalidBlsSignature(DefaultCodespace, "Signature must be 48 bytes")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgClaimOrder) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgClaimOrder) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Merchant}
+}
+
+// Balance returns the ChannelBalance the Signature is expected to sign
+func (msg MsgClaimOrder) Balance() ChannelBalance {
+	return NewChannelBalance(msg.ChannelID, msg.Nonce, msg.MerchantBalance, msg.CustomerBalance)
+}
+
+// BatchClaim is a single channel close within a MsgBatchClaim
+type BatchClaim struct {
+	// Used to lookup the escrow in the KV
+	ChannelID       string    `json:"channelID"`
+	Nonce           uint64    `json:"nonce"`
+	MerchantBalance sdk.Coins `json:"merchantBalance"`
+	CustomerBalance sdk.Coins `json:"customerBalance"`
+}
+
+// NewBatchClaim is a constructor
+func NewBatchClaim(channelID string, nonce uint64, merchantBalance sdk.Coins, customerBalance sdk.Coins) BatchClaim {
+	return BatchClaim{
+		ChannelID:       channelID,
+		Nonce:           nonce,
+		MerchantBalance: merchantBalance,
+		CustomerBalance: customerBalance,
+	}
+}
+
+// Balance returns the ChannelBalance the claim closes the channel with
+func (c BatchClaim) Balance() ChannelBalance {
+	return NewChannelBalance(c.ChannelID, c.Nonce, c.MerchantBalance, c.CustomerBalance)
+}
+
+// MsgBatchClaim defines a BatchClaim message, used by the merchant to claim many filled orders at once
+type MsgBatchClaim struct {
+	Merchant sdk.AccAddress `json:"merchant"`
+	Claims   []BatchClaim   `json:"claims"`
+	// Bls12-381 signatures over each claim's ChannelBalance, made by each channel's customer with the
+	// WalletCommit key and aggregated into one by the merchant
+	Signature []byte `json:"signature"` // 48 bytes
+}
+
+// NewMsgBatchClaim is a constructor
+func NewMsgBatchClaim(merchant sdk.AccAddress, claims []BatchClaim, signature []byte) MsgBatchClaim {
+	return MsgBatchClaim{
+		Merchant:  merchant,
+		Claims:    claims,
+		Signature: signature,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgBatchClaim) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgBatchClaim) Type() string { return "batch_claim" }
+
+// ValidateBasic checks that there are claims for distinct channels with valid balances, the signature is 48 bytes
+// and the merchant is not empty
+func (msg MsgBatchClaim) ValidateBasic() sdk.Error {
+	if msg.Merchant.Empty() {
+		return sdk.ErrInvalidAddress(msg.Merchant.String())
+	}
+	if len(msg.Claims) == 0 {
+		return sdk.ErrUnknownRequest("Claims cannot be empty")
+	}
+	seen := make(map[string]bool, len(msg.Claims))
+	for _, claim := range msg.Claims {
+		if len(claim.ChannelID) == 0 {
+			return sdk.ErrUnknownRequest("ChannelID cannot be empty")
+		}
+		if seen[claim.ChannelID] {
+			return sdk.ErrUnknownRequest(fmt.Sprintf("Channel %s is claimed more than once", claim.ChannelID))
+		}
+		seen[claim.ChannelID] = true
+		if !claim.MerchantBalance.IsValid() || !claim.CustomerBalance.IsValid() {
+			return sdk.ErrInvalidCoins("Balances must be valid coins")
+		}
+		if claim.MerchantBalance.Empty() && claim.CustomerBalance.Empty() {
+			return sdk.ErrInsufficientCoins("Balances cannot both be empty")
+		}
+	}
+	if len(msg.Signature) != len(Bls12381Signature{}) {
+		return ErrInvalidBlsSignature(DefaultCodespace, "Signature must be 48 bytes")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgBatchClaim) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgBatchClaim) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Merchant}
+}
+
+// MsgDisputeClose defines a DisputeClose message, used by the customer to override a pending close with a newer balance
+type MsgDisputeClose struct {
+	Customer sdk.AccAddress `json:"customer"`
+	// Used to lookup the escrow in the KV
+	ChannelID       string    `json:"channelID"`
+	Nonce           uint64    `json:"nonce"`
+	MerchantBalance sdk.Coins `json:"merchantBalance"`
+	CustomerBalance sdk.Coins `json:"customerBalance"`
+	// Bls12-381 signature over the newer ChannelBalance, made by the merchant with the ChannelState key
+	Signature []byte `json:"signature"` // 48 bytes
+}
+
+// NewMsgDisputeClose is a constructor
+func NewMsgDisputeClose(customer sdk.AccAddress, channelID string, nonce uint64, merchantBalance sdk.Coins, customerBalance sdk.Coins, signature []byte) MsgDisputeClose {
+	return MsgDisputeClose{
+		Customer:        customer,
+		ChannelID:       channelID,
+		Nonce:           nonce,
+		MerchantBalance: merchantBalance,
+		CustomerBalance: customerBalance,
+		Signature:       signature,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgDisputeClose) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgDisputeClose) Type() string { return "dispute_close" }
+
+// ValidateBasic checks that the balances are valid, the signature is 48 bytes and addresses are not empty
+func (msg MsgDisputeClose) ValidateBasic() sdk.Error {
+	if msg.Customer.Empty() {
+		return sdk.ErrInvalidAddress(msg.Customer.String())
+	}
+	if len(msg.ChannelID) == 0 {
+		return sdk.ErrUnknownRequest("ChannelID cannot be empty")
+	}
+	if !msg.MerchantBalance.IsValid() || !msg.CustomerBalance.IsValid() {
+		return sdk.ErrInvalidCoins("Balances must be valid coins")
+	}
+	if msg.MerchantBalance.Empty() && msg.CustomerBalance.Empty() {
+		return sdk.ErrInsufficientCoins("Balances cannot both be empty")
+	}
+	if len(msg.Signature) != len(Bls12381Signature{}) {
+		return ErrInvalidBlsSignature(DefaultCodespace, "Signature must be 48 bytes")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgDisputeClose) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgDisputeClose) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Customer}
+}
+
+// Balance returns the ChannelBalance the Signature is expected to sign
+func (msg MsgDisputeClose) Balance() ChannelBalance {
+	return NewChannelBalance(msg.ChannelID, msg.Nonce, msg.MerchantBalance, msg.CustomerBalance)
+}
+
+// MsgCustomerClose defines a CustomerClose message, used by the customer to close a filled escrow on their own
+type MsgCustomerClose struct {
+	Customer sdk.AccAddress `json:"customer"`
+	// Used to lookup the escrow in the KV
+	ChannelID       string    `json:"channelID"`
+	Nonce           uint64    `json:"nonce"`
+	MerchantBalance sdk.Coins `json:"merchantBalance"`
+	CustomerBalance sdk.Coins `json:"customerBalance"`
+	// Bls12-381 signature over the ChannelBalance, made by the merchant with the ChannelState key
+	// when the channel moved to this balance
+	Signature []byte `json:"signature"` // 48 bytes
+}
+
+// NewMsgCustomerClose is a constructor
+func NewMsgCustomerClose(customer sdk.AccAddress, channelID string, nonce uint64, merchantBalance sdk.Coins, customerBalance sdk.Coins, signature []byte) MsgCustomerClose {
+	return MsgCustomerClose{
+		Customer:        customer,
+		ChannelID:       channelID,
+		Nonce:           nonce,
+		MerchantBalance: merchantBalance,
+		CustomerBalance: customerBalance,
+		Signature:       signature,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgCustomerClose) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgCustomerClose) Type() string { return "customer_close" }
+
+// ValidateBasic checks that the balances are valid, the signature is 48 bytes and addresses are not empty
+func (msg MsgCustomerClose) ValidateBasic() sdk.Error {
+	if msg.Customer.Empty() {
+		return sdk.ErrInvalidAddress(msg.Customer.String())
+	}
+	if len(msg.ChannelID) == 0 {
+		return sdk.ErrUnknownRequest("ChannelID cannot be empty")
+	}
+	if !msg.MerchantBalance.IsValid() || !msg.CustomerBalance.IsValid() {
+		return sdk.ErrInvalidCoins("Balances must be valid coins")
+	}
+	if msg.MerchantBalance.Empty() && msg.CustomerBalance.Empty() {
+		return sdk.ErrInsufficientCoins("Balances cannot both be empty")
+	}
+	if len(msg.Signature) != len(Bls12381Signature{}) {
+		return ErrInvalidBlsSignature(DefaultCodespace, "Signature must be 48 bytes")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgCustomerClose) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgCustomerClose) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Customer}
+}
+
+// Balance returns the ChannelBalance the Signature is expected to sign
+func (msg MsgCustomerClose) Balance() ChannelBalance {
+	return NewChannelBalance(msg.ChannelID, msg.Nonce, msg.MerchantBalance, msg.CustomerBalance)
+}
+
+// MsgRevokeClose defines a RevokeClose message, used by the merchant to punish a customer close on a revoked wallet state
+type MsgRevokeClose struct {
+	Merchant sdk.AccAddress `json:"merchant"`
+	// Used to lookup the escrow in the KV
+	ChannelID string `json:"channelID"`
+	// Bls12-381 signature over the Revocation of the closing nonce, made with the WalletCommit key
+	RevocationToken []byte `json:"revocationToken"` // 48 bytes
+}
+
+// NewMsgRevokeClose is a constructor
+func NewMsgRevokeClose(merchant sdk.AccAddress, channelID string, revocationToken []byte) MsgRevokeClose {
+	return MsgRevokeClose{
+		Merchant:        merchant,
+		ChannelID:       channelID,
+		RevocationToken: revocationToken,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgRevokeClose) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgRevokeClose) Type() string { return "revoke_close" }
+
+// ValidateBasic checks that the revocation token is 48 bytes and the merchant is not empty
+func (msg MsgRevokeClose) ValidateBasic() sdk.Error {
+	if msg.Merchant.Empty() {
+		return sdk.ErrInvalidAddress(msg.Merchant.String())
+	}
+	if len(msg.ChannelID) == 0 {
+		return sdk.ErrUnknownRequest("ChannelID cannot be empty")
+	}
+	if len(msg.RevocationToken) != len(Bls12381Signature{}) {
+		return ErrInvalidBlsSignature(DefaultCodespace, "Revocation token must be 48 bytes")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgRevokeClose) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgRevokeClose) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Merchant}
+}
+
+// MsgMutualClose defines a MutualClose message, used when both channel parties agree on the final balance
+type MsgMutualClose struct {
+	Merchant sdk.AccAddress `json:"merchant"`
+	Customer sdk.AccAddress `json:"customer"`
+	// Used to lookup the escrow in the KV
+	ChannelID       string    `json:"channelID"`
+	MerchantBalance sdk.Coins `json:"merchantBalance"`
+	CustomerBalance sdk.Coins `json:"customerBalance"`
+}
+
+// NewMsgMutualClose is a constructor
+func NewMsgMutualClose(merchant sdk.AccAddress, customer sdk.AccAddress, channelID string, merchantBalance sdk.Coins, customerBalance sdk.Coins) MsgMutualClose {
+	return MsgMutualClose{
+		Merchant:        merchant,
+		Customer:        customer,
+		ChannelID:       channelID,
+		MerchantBalance: merchantBalance,
+		CustomerBalance: customerBalance,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgMutualClose) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgMutualClose) Type() string { return "mutual_close" }
+
+// ValidateBasic checks that the balances are valid and addresses are not empty
+func (msg MsgMutualClose) ValidateBasic() sdk.Error {
+	if len(msg.ChannelID) == 0 {
+		return sdk.ErrUnknownRequest("ChannelID cannot be empty")
+	}
+	if msg.Merchant.Empty() {
+		return sdk.ErrInvalidAddress(msg.Merchant.String())
+	}
+	if msg.Customer.Empty() {
+		return sdk.ErrInvalidAddress(msg.Customer.String())
+	}
+	if !msg.MerchantBalance.IsValid() || !msg.CustomerBalance.IsValid() {
+		return sdk.ErrInvalidCoins("Balances must be valid coins")
+	}
+	if msg.MerchantBalance.Empty() && msg.CustomerBalance.Empty() {
+		return sdk.ErrInsufficientCoins("Balances cannot both be empty")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgMutualClose) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required. Both channel parties must sign, so no Bls12-381 signature is needed
+func (msg MsgMutualClose) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Merchant, msg.Customer}
+}
+
+// Balance returns the agreed ChannelBalance. Mutual closes pay out immediately, so the nonce is not used
+func (msg MsgMutualClose) Balance() ChannelBalance {
+	return NewChannelBalance(msg.ChannelID, 0, msg.MerchantBalance, msg.CustomerBalance)
+}
+
+// validateBls12381PubKey checks that bz is the length of a serialized Bls12-381 public key. Whether it is on the
+// curve is left to the handler, which charges gas for deserializing it
+func validateBls12381PubKey(bz []byte) sdk.Error {
+	var pubKey Bls12381PubKey
+	if len(bz) != len(pubKey) {
+		return ErrInvalidBlsPubKey(DefaultCodespace, fmt.Sprintf("Bls12-381 public key must be %d bytes, got %d", len(pubKey), len(bz)))
+	}
+	return nil
 }
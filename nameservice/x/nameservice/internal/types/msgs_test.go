@@ -4,11 +4,14 @@ import (
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/phoreproject/bls/g2pubs"
 	"github.com/stretchr/testify/require"
 )
 
 var name = "maTurtle"
 
+var channelID = NewChannelID(sdk.AccAddress([]byte("me")), []byte("token"), 0)
+
 func TestMsgSetName(t *testing.T) {
 	value := "1"
 	acc := sdk.AccAddress([]byte("me"))
@@ -81,6 +84,9 @@ func TestMsgBuyNameValidation(t *testing.T) {
 	}{
 		{true, NewMsgBuyName(name, coins, acc)},
 		{true, NewMsgBuyName(name2, coins, acc2)},
+		{false, NewMsgBuyName(name, sdk.NewCoins(), acc)},
+		{false, NewMsgBuyName(name, coins.Add(sdk.NewCoins(sdk.NewInt64Coin("btc", 1))), acc)},
+		{false, NewMsgBuyName(name, coins, nil)},
 	}
 
 	for _, tc := range cases {
@@ -145,3 +151,352 @@ func TestMsgDeleteNameGetSignBytes(t *testing.T) {
 
 	require.Equal(t, expected, string(res))
 }
+
+func TestMsgRenewName(t *testing.T) {
+	acc := sdk.AccAddress([]byte("me"))
+	var msg = NewMsgRenewName(name, acc)
+
+	require.Equal(t, msg.Route(), RouterKey)
+	require.Equal(t, msg.Type(), "renew_name")
+}
+
+func TestMsgRenewNameValidation(t *testing.T) {
+	acc := sdk.AccAddress([]byte("me"))
+
+	cases := []struct {
+		valid bool
+		tx    MsgRenewName
+	}{
+		{true, NewMsgRenewName(name, acc)},
+		{false, NewMsgRenewName("", acc)},
+		{false, NewMsgRenewName(name, nil)},
+	}
+
+	for _, tc := range cases {
+		err := tc.tx.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err)
+		} else {
+			require.NotNil(t, err)
+		}
+	}
+}
+
+func TestMsgRenewNameGetSignBytes(t *testing.T) {
+	acc := sdk.AccAddress([]byte("me"))
+	var msg = NewMsgRenewName(name, acc)
+	res := msg.GetSignBytes()
+
+	expected := `{"type":"nameservice/RenewName","value":{"name":"maTurtle","owner":"cosmos1d4js690r9j"}}`
+
+	require.Equal(t, expected, string(res))
+}
+
+func TestMsgCommitBidValidation(t *testing.T) {
+	acc := sdk.AccAddress([]byte("me"))
+	coins := sdk.NewCoins(sdk.NewInt64Coin("atom", 10))
+	twoDenoms := sdk.NewCoins(sdk.NewInt64Coin("atom", 10), sdk.NewInt64Coin("eth", 1))
+	bidHash := NewBidHash(name, acc, coins, []byte("salt"))
+
+	cases := []struct {
+		valid bool
+		tx    MsgCommitBid
+	}{
+		{true, NewMsgCommitBid(name, acc, bidHash, coins)},
+		{false, NewMsgCommitBid("", acc, bidHash, coins)},
+		{false, NewMsgCommitBid(name, nil, bidHash, coins)},
+		{false, NewMsgCommitBid(name, acc, bidHash[:31], coins)},
+		{false, NewMsgCommitBid(name, acc, bidHash, sdk.NewCoins())},
+		{false, NewMsgCommitBid(name, acc, bidHash, twoDenoms)},
+	}
+
+	for _, tc := range cases {
+		err := tc.tx.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err)
+		} else {
+			require.NotNil(t, err)
+		}
+	}
+}
+
+func TestMsgRevealBidValidation(t *testing.T) {
+	acc := sdk.AccAddress([]byte("me"))
+	coins := sdk.NewCoins(sdk.NewInt64Coin("atom", 10))
+	salt := []byte("salt")
+
+	cases := []struct {
+		valid bool
+		tx    MsgRevealBid
+	}{
+		{true, NewMsgRevealBid(name, acc, coins, salt)},
+		{true, NewMsgRevealBid(name, acc, coins, nil)},
+		{false, NewMsgRevealBid("", acc, coins, salt)},
+		{false, NewMsgRevealBid(name, nil, coins, salt)},
+		{false, NewMsgRevealBid(name, acc, sdk.NewCoins(), salt)},
+	}
+
+	for _, tc := range cases {
+		err := tc.tx.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err)
+		} else {
+			require.NotNil(t, err)
+		}
+	}
+}
+
+func TestNewBidHash(t *testing.T) {
+	acc := sdk.AccAddress([]byte("me"))
+	acc2 := sdk.AccAddress([]byte("you"))
+	coins := sdk.NewCoins(sdk.NewInt64Coin("atom", 10))
+	salt := []byte("salt")
+	bidHash := NewBidHash(name, acc, coins, salt)
+
+	require.Len(t, bidHash, 32)
+	require.Equal(t, bidHash, NewBidHash(name, acc, coins, salt))
+	require.NotEqual(t, bidHash, NewBidHash("a", acc, coins, salt))
+	require.NotEqual(t, bidHash, NewBidHash(name, acc2, coins, salt))
+	require.NotEqual(t, bidHash, NewBidHash(name, acc, sdk.NewCoins(sdk.NewInt64Coin("atom", 11)), salt))
+	require.NotEqual(t, bidHash, NewBidHash(name, acc, coins, []byte("pepper")))
+}
+
+func TestMsgCreateOrderValidation(t *testing.T) {
+	acc := sdk.AccAddress([]byte("me"))
+	coins := sdk.NewCoins(sdk.NewInt64Coin("atom", 10))
+	pubKey := g2pubs.PrivToPub(g2pubs.DeriveSecretKey([32]byte{1})).Serialize()
+	token := []byte("token")
+	proof := make([]byte, 48)
+
+	cases := []struct {
+		valid bool
+		tx    MsgCreateOrder
+	}{
+		{true, NewMsgCreateOrder(acc, pubKey[:], token, proof, coins)},
+		{false, NewMsgCreateOrder(nil, pubKey[:], token, proof, coins)},
+		{false, NewMsgCreateOrder(acc, pubKey[:95], token, proof, coins)},
+		// Whether the ChannelState is on the curve is checked by the handler
+		{true, NewMsgCreateOrder(acc, make([]byte, 96), token, proof, coins)},
+		{false, NewMsgCreateOrder(acc, pubKey[:], nil, proof, coins)},
+		{false, NewMsgCreateOrder(acc, pubKey[:], token, make([]byte, 47), coins)},
+		{false, NewMsgCreateOrder(acc, pubKey[:], token, proof, sdk.Coins{})},
+	}
+
+	for _, tc := range cases {
+		err := tc.tx.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err)
+		} else {
+			require.NotNil(t, err)
+		}
+	}
+}
+
+func TestMsgFillOrderValidation(t *testing.T) {
+	acc := sdk.AccAddress([]byte("you"))
+	coins := sdk.NewCoins(sdk.NewInt64Coin("atom", 10))
+	commit := g2pubs.PrivToPub(g2pubs.DeriveSecretKey([32]byte{2})).Serialize()
+
+	cases := []struct {
+		valid bool
+		tx    MsgFillOrder
+	}{
+		{true, NewMsgFillOrder(channelID, acc, commit[:], coins)},
+		{false, NewMsgFillOrder(channelID, nil, commit[:], coins)},
+		{false, NewMsgFillOrder("", acc, commit[:], coins)},
+		{false, NewMsgFillOrder(channelID, acc, make([]byte, 48), coins)},
+		// Whether the WalletCommit is on the curve is checked by the handler
+		{true, NewMsgFillOrder(channelID, acc, make([]byte, 96), coins)},
+		{false, NewMsgFillOrder(channelID, acc, commit[:], sdk.Coins{})},
+	}
+
+	for _, tc := range cases {
+		err := tc.tx.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err)
+		} else {
+			require.NotNil(t, err)
+		}
+	}
+}
+
+func TestMsgClaimOrder(t *testing.T) {
+	acc := sdk.AccAddress([]byte("me"))
+	coins := sdk.NewCoins(sdk.NewInt64Coin("atom", 10))
+	var msg = NewMsgClaimOrder(acc, channelID, 1, coins, coins, make([]byte, 48))
+
+	require.Equal(t, msg.Route(), RouterKey)
+	require.Equal(t, msg.Type(), "claim_order")
+	require.Equal(t, []sdk.AccAddress{acc}, msg.GetSigners())
+}
+
+func TestMsgClaimOrderValidation(t *testing.T) {
+	acc := sdk.AccAddress([]byte("me"))
+	coins := sdk.NewCoins(sdk.NewInt64Coin("atom", 10))
+	sig := make([]byte, 48)
+
+	cases := []struct {
+		valid bool
+		tx    MsgClaimOrder
+	}{
+		{true, NewMsgClaimOrder(acc, channelID, 1, coins, coins, sig)},
+		{true, NewMsgClaimOrder(acc, channelID, 1, coins, sdk.Coins{}, sig)},
+		{true, NewMsgClaimOrder(acc, channelID, 1, sdk.Coins{}, coins, sig)},
+		{false, NewMsgClaimOrder(nil, channelID, 1, coins, coins, sig)},
+		{false, NewMsgClaimOrder(acc, channelID, 1, sdk.Coins{}, sdk.Coins{}, sig)},
+		{false, NewMsgClaimOrder(acc, channelID, 1, coins, coins, make([]byte, 47))},
+		{false, NewMsgClaimOrder(acc, "", 1, coins, coins, sig)},
+		{false, NewMsgClaimOrder(acc, channelID, 1, sdk.Coins{sdk.Coin{Denom: "atom", Amount: sdk.NewInt(-1)}}, coins, sig)},
+	}
+
+	for _, tc := range cases {
+		err := tc.tx.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err)
+		} else {
+			require.NotNil(t, err)
+		}
+	}
+}
+
+func TestMsgBatchClaimValidation(t *testing.T) {
+	acc := sdk.AccAddress([]byte("me"))
+	coins := sdk.NewCoins(sdk.NewInt64Coin("atom", 10))
+	sig := make([]byte, 48)
+	claim := NewBatchClaim(channelID, 1, coins, coins)
+	other := NewBatchClaim(NewChannelID(acc, []byte("token"), 1), 1, coins, sdk.Coins{})
+
+	cases := []struct {
+		valid bool
+		tx    MsgBatchClaim
+	}{
+		{true, NewMsgBatchClaim(acc, []BatchClaim{claim}, sig)},
+		{true, NewMsgBatchClaim(acc, []BatchClaim{claim, other}, sig)},
+		{false, NewMsgBatchClaim(nil, []BatchClaim{claim}, sig)},
+		{false, NewMsgBatchClaim(acc, nil, sig)},
+		{false, NewMsgBatchClaim(acc, []BatchClaim{claim, claim}, sig)},
+		{false, NewMsgBatchClaim(acc, []BatchClaim{NewBatchClaim("", 1, coins, coins)}, sig)},
+		{false, NewMsgBatchClaim(acc, []BatchClaim{NewBatchClaim(channelID, 1, sdk.Coins{}, sdk.Coins{})}, sig)},
+		{false, NewMsgBatchClaim(acc, []BatchClaim{claim}, make([]byte, 47))},
+	}
+
+	for _, tc := range cases {
+		err := tc.tx.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err)
+		} else {
+			require.NotNil(t, err)
+		}
+	}
+}
+
+func TestValidateAggregateSignature(t *testing.T) {
+	coins := sdk.NewCoins(sdk.NewInt64Coin("atom", 10))
+	var pubKeys []Bls12381PubKey
+	var msgs [][]byte
+	var sigs []*g2pubs.Signature
+	for i := byte(1); i <= 3; i++ {
+		key := g2pubs.DeriveSecretKey([32]byte{i})
+		msg := NewChannelBalance(NewChannelID(sdk.AccAddress([]byte("me")), []byte{i}, 0), 1, coins, coins).GetSignBytes()
+		pubKeys = append(pubKeys, g2pubs.PrivToPub(key).Serialize())
+		msgs = append(msgs, msg)
+		sigs = append(sigs, g2pubs.Sign(msg, key))
+	}
+	sig := g2pubs.AggregateSignatures(sigs).Serialize()
+
+	require.True(t, ValidateAggregateSignature(pubKeys, sig, msgs))
+	require.False(t, ValidateAggregateSignature(pubKeys[:2], sig, msgs[:2]))
+	require.False(t, ValidateAggregateSignature([]Bls12381PubKey{pubKeys[1], pubKeys[0], pubKeys[2]}, sig, msgs))
+}
+
+func TestMsgDisputeClose(t *testing.T) {
+	acc2 := sdk.AccAddress([]byte("you"))
+	coins := sdk.NewCoins(sdk.NewInt64Coin("atom", 10))
+	var msg = NewMsgDisputeClose(acc2, channelID, 2, coins, coins, make([]byte, 48))
+
+	require.Equal(t, msg.Route(), RouterKey)
+	require.Equal(t, msg.Type(), "dispute_close")
+	require.Equal(t, []sdk.AccAddress{acc2}, msg.GetSigners())
+	require.Equal(t, NewChannelBalance(channelID, 2, coins, coins), msg.Balance())
+}
+
+func TestMsgCustomerClose(t *testing.T) {
+	acc2 := sdk.AccAddress([]byte("you"))
+	coins := sdk.NewCoins(sdk.NewInt64Coin("atom", 10))
+	var msg = NewMsgCustomerClose(acc2, channelID, 3, coins, coins, make([]byte, 48))
+
+	require.Equal(t, msg.Route(), RouterKey)
+	require.Equal(t, msg.Type(), "customer_close")
+	require.Equal(t, []sdk.AccAddress{acc2}, msg.GetSigners())
+	require.Equal(t, NewChannelBalance(channelID, 3, coins, coins), msg.Balance())
+}
+
+func TestMsgRevokeCloseValidation(t *testing.T) {
+	acc := sdk.AccAddress([]byte("me"))
+
+	cases := []struct {
+		valid bool
+		tx    MsgRevokeClose
+	}{
+		{true, NewMsgRevokeClose(acc, channelID, make([]byte, 48))},
+		{false, NewMsgRevokeClose(nil, channelID, make([]byte, 48))},
+		{false, NewMsgRevokeClose(acc, channelID, nil)},
+	}
+
+	for _, tc := range cases {
+		err := tc.tx.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err)
+		} else {
+			require.NotNil(t, err)
+		}
+	}
+}
+
+func TestMsgMutualClose(t *testing.T) {
+	acc := sdk.AccAddress([]byte("me"))
+	acc2 := sdk.AccAddress([]byte("you"))
+	coins := sdk.NewCoins(sdk.NewInt64Coin("atom", 10))
+	var msg = NewMsgMutualClose(acc, acc2, channelID, coins, coins)
+
+	require.Equal(t, msg.Route(), RouterKey)
+	require.Equal(t, msg.Type(), "mutual_close")
+	require.Equal(t, []sdk.AccAddress{acc, acc2}, msg.GetSigners())
+}
+
+func TestMsgMutualCloseValidation(t *testing.T) {
+	acc := sdk.AccAddress([]byte("me"))
+	acc2 := sdk.AccAddress([]byte("you"))
+	coins := sdk.NewCoins(sdk.NewInt64Coin("atom", 10))
+
+	cases := []struct {
+		valid bool
+		tx    MsgMutualClose
+	}{
+		{true, NewMsgMutualClose(acc, acc2, channelID, coins, coins)},
+		{true, NewMsgMutualClose(acc, acc2, channelID, sdk.Coins{}, coins)},
+		{false, NewMsgMutualClose(nil, acc2, channelID, coins, coins)},
+		{false, NewMsgMutualClose(acc, nil, channelID, coins, coins)},
+		{false, NewMsgMutualClose(acc, acc2, channelID, sdk.Coins{}, sdk.Coins{})},
+	}
+
+	for _, tc := range cases {
+		err := tc.tx.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err)
+		} else {
+			require.NotNil(t, err)
+		}
+	}
+}
+
+func TestNewChannelID(t *testing.T) {
+	acc := sdk.AccAddress([]byte("me"))
+	acc2 := sdk.AccAddress([]byte("you"))
+
+	require.Equal(t, channelID, NewChannelID(acc, []byte("token"), 0))
+	require.Len(t, channelID, 64)
+	require.NotEqual(t, channelID, NewChannelID(acc, []byte("token"), 1))
+	require.NotEqual(t, channelID, NewChannelID(acc, []byte("other"), 0))
+	require.NotEqual(t, channelID, NewChannelID(acc2, []byte("token"), 0))
+}
@@ -0,0 +1,229 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// DefaultParamspace is the name of the module's params subspace
+const DefaultParamspace = ModuleName
+
+// Parameter store keys
+var (
+	KeyMinNamePrice        = []byte("MinNamePrice")
+	KeyNameLifetime        = []byte("NameLifetime")
+	KeyNameGracePeriod     = []byte("NameGracePeriod")
+	KeyNameRenewalFee      = []byte("NameRenewalFee")
+	KeyAuctionOnly         = []byte("AuctionOnly")
+	KeyAuctionCommitPeriod = []byte("AuctionCommitPeriod")
+	KeyAuctionRevealPeriod = []byte("AuctionRevealPeriod")
+	KeyResaleFeeRate       = []byte("ResaleFeeRate")
+	KeyAllowedEscrowDenoms = []byte("AllowedEscrowDenoms")
+	KeyMinEscrowAmount     = []byte("MinEscrowAmount")
+	KeyMaxEscrowAmount     = []byte("MaxEscrowAmount")
+	KeyDisputePeriod       = []byte("DisputePeriod")
+	KeyBlsPairingGas       = []byte("BlsPairingGas")
+	KeyBlsPubKeyGas        = []byte("BlsPubKeyGas")
+)
+
+// Params are the governable parameters of the nameservice module
+type Params struct {
+	// Initial starting price for a name that was never previously owned
+	MinNamePrice sdk.Coins `json:"min_name_price"`
+	// Number of blocks a name is owned for after it is bought or renewed
+	NameLifetime int64 `json:"name_lifetime"`
+	// Number of blocks after a name expires during which its owner can still renew it before it is released
+	NameGracePeriod int64 `json:"name_grace_period"`
+	// Fee paid to the fee collector for renewing a name
+	NameRenewalFee sdk.Coins `json:"name_renewal_fee"`
+	// Whether names can only be bought by sealed-bid auction, rejecting MsgBuyName
+	AuctionOnly bool `json:"auction_only"`
+	// Number of blocks an auction takes sealed bids for after the first one, and then waits for them to be revealed
+	AuctionCommitPeriod int64 `json:"auction_commit_period"`
+	AuctionRevealPeriod int64 `json:"auction_reveal_period"`
+	// Share of the price of a resold name paid to the fee collector instead of the previous owner.
+	// The whole price of a name nobody owns goes to the fee collector
+	ResaleFeeRate sdk.Dec `json:"resale_fee_rate"`
+	// Denominations an order can escrow. Any denomination is allowed when empty
+	AllowedEscrowDenoms []string `json:"allowed_escrow_denoms"`
+	// Bounds on the amount a merchant escrows when creating an order. A zero maximum means no bound
+	MinEscrowAmount sdk.Int `json:"min_escrow_amount"`
+	MaxEscrowAmount sdk.Int `json:"max_escrow_amount"`
+	// Number of blocks a channel close waits for disputes before the escrow is paid out
+	DisputePeriod int64 `json:"dispute_period"`
+	// Gas charged for each pairing computed while verifying a Bls12-381 signature.
+	// Verifying a single signature takes two, an aggregate of n signatures n+1
+	BlsPairingGas uint64 `json:"bls_pairing_gas"`
+	// Gas charged for each Bls12-381 public key deserialized while verifying a signature
+	BlsPubKeyGas uint64 `json:"bls_pubkey_gas"`
+}
+
+// ParamKeyTable returns the key table for the nameservice module's params
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// NewParams creates a new Params
+func NewParams(minNamePrice sdk.Coins, nameLifetime int64, nameGracePeriod int64, nameRenewalFee sdk.Coins,
+	auctionOnly bool, auctionCommitPeriod int64, auctionRevealPeriod int64, resaleFeeRate sdk.Dec,
+	allowedEscrowDenoms []string, minEscrowAmount sdk.Int, maxEscrowAmount sdk.Int,
+	disputePeriod int64, blsPairingGas uint64, blsPubKeyGas uint64) Params {
+
+	return Params{
+		MinNamePrice:        minNamePrice,
+		NameLifetime:        nameLifetime,
+		NameGracePeriod:     nameGracePeriod,
+		NameRenewalFee:      nameRenewalFee,
+		AuctionOnly:         auctionOnly,
+		AuctionCommitPeriod: auctionCommitPeriod,
+		AuctionRevealPeriod: auctionRevealPeriod,
+		ResaleFeeRate:       resaleFeeRate,
+		AllowedEscrowDenoms: allowedEscrowDenoms,
+		MinEscrowAmount:     minEscrowAmount,
+		MaxEscrowAmount:     maxEscrowAmount,
+		DisputePeriod:       disputePeriod,
+		BlsPairingGas:       blsPairingGas,
+		BlsPubKeyGas:        blsPubKeyGas,
+	}
+}
+
+// DefaultParams returns the default nameservice module parameters
+func DefaultParams() Params {
+	return Params{
+		MinNamePrice:        sdk.Coins{sdk.NewInt64Coin("nametoken", 1)},
+		NameLifetime:        500000,
+		NameGracePeriod:     50000,
+		NameRenewalFee:      sdk.Coins{sdk.NewInt64Coin("nametoken", 1)},
+		AuctionOnly:         false,
+		AuctionCommitPeriod: 100,
+		AuctionRevealPeriod: 100,
+		ResaleFeeRate:       sdk.NewDecWithPrec(5, 2),
+		AllowedEscrowDenoms: []string{},
+		MinEscrowAmount:     sdk.OneInt(),
+		MaxEscrowAmount:     sdk.ZeroInt(),
+		DisputePeriod:       100,
+		BlsPairingGas:       20000,
+		BlsPubKeyGas:        2000,
+	}
+}
+
+// ValidateParams checks that the params are usable
+func ValidateParams(params Params) error {
+	if params.MinNamePrice.Len() != 1 || !params.MinNamePrice.IsValid() {
+		return fmt.Errorf("nameservice parameter MinNamePrice must be a single positive denomination, is %s", params.MinNamePrice)
+	}
+	if params.NameLifetime <= 0 {
+		return fmt.Errorf("nameservice parameter NameLifetime must be positive, is %d", params.NameLifetime)
+	}
+	if params.NameGracePeriod < 0 {
+		return fmt.Errorf("nameservice parameter NameGracePeriod must be non-negative, is %d", params.NameGracePeriod)
+	}
+	if !params.NameRenewalFee.IsValid() {
+		return fmt.Errorf("nameservice parameter NameRenewalFee must be valid coins, is %s", params.NameRenewalFee)
+	}
+	if params.AuctionCommitPeriod <= 0 {
+		return fmt.Errorf("nameservice parameter AuctionCommitPeriod must be positive, is %d", params.AuctionCommitPeriod)
+	}
+	if params.AuctionRevealPeriod <= 0 {
+		return fmt.Errorf("nameservice parameter AuctionRevealPeriod must be positive, is %d", params.AuctionRevealPeriod)
+	}
+	if params.ResaleFeeRate.IsNil() || params.ResaleFeeRate.IsNegative() || params.ResaleFeeRate.GT(sdk.OneDec()) {
+		return fmt.Errorf("nameservice parameter ResaleFeeRate must be between 0 and 1, is %s", params.ResaleFeeRate)
+	}
+	for _, denom := range params.AllowedEscrowDenoms {
+		if !(sdk.Coins{sdk.Coin{Denom: denom, Amount: sdk.OneInt()}}).IsValid() {
+			return fmt.Errorf("nameservice parameter AllowedEscrowDenoms has invalid denomination %s", denom)
+		}
+	}
+	if params.MinEscrowAmount == (sdk.Int{}) || params.MinEscrowAmount.IsNegative() {
+		return fmt.Errorf("nameservice parameter MinEscrowAmount must be a non-negative integer")
+	}
+	if params.MaxEscrowAmount == (sdk.Int{}) || params.MaxEscrowAmount.IsNegative() {
+		return fmt.Errorf("nameservice parameter MaxEscrowAmount must be a non-negative integer")
+	}
+	if params.MaxEscrowAmount.IsPositive() && params.MaxEscrowAmount.LT(params.MinEscrowAmount) {
+		return fmt.Errorf("nameservice parameter MaxEscrowAmount must be zero or at least MinEscrowAmount")
+	}
+	if params.DisputePeriod <= 0 {
+		return fmt.Errorf("nameservice parameter DisputePeriod must be positive, is %d", params.DisputePeriod)
+	}
+	if params.BlsPairingGas == 0 {
+		return fmt.Errorf("nameservice parameter BlsPairingGas must be positive")
+	}
+	return nil
+}
+
+// ValidateEscrowAmount checks that an order escrows a single allowed denomination within the escrow bounds
+func (p Params) ValidateEscrowAmount(amount sdk.Coins) sdk.Error {
+	if amount.Len() != 1 {
+		return ErrDenomMismatch(DefaultCodespace, "Incorrect number of denominations. Must be 1")
+	}
+	coin := amount[0]
+	if len(p.AllowedEscrowDenoms) != 0 && !p.IsAllowedEscrowDenom(coin.Denom) {
+		return ErrDenomMismatch(DefaultCodespace, fmt.Sprintf("Denomination %s cannot be escrowed. Must be one of %s", coin.Denom, strings.Join(p.AllowedEscrowDenoms, ", ")))
+	}
+	if coin.Amount.LT(p.MinEscrowAmount) {
+		return ErrInvalidEscrowAmount(DefaultCodespace, fmt.Sprintf("Escrow amount must be at least %s%s", p.MinEscrowAmount, coin.Denom))
+	}
+	if p.MaxEscrowAmount.IsPositive() && coin.Amount.GT(p.MaxEscrowAmount) {
+		return ErrInvalidEscrowAmount(DefaultCodespace, fmt.Sprintf("Escrow amount must be at most %s%s", p.MaxEscrowAmount, coin.Denom))
+	}
+	return nil
+}
+
+// IsAllowedEscrowDenom returns whether denom is in AllowedEscrowDenoms
+func (p Params) IsAllowedEscrowDenom(denom string) bool {
+	for _, allowed := range p.AllowedEscrowDenoms {
+		if allowed == denom {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Params) String() string {
+	return fmt.Sprintf(`Nameservice Params:
+  Min Name Price:         %s
+  Name Lifetime:          %d
+  Name Grace Period:      %d
+  Name Renewal Fee:       %s
+  Auction Only:           %t
+  Auction Commit Period:  %d
+  Auction Reveal Period:  %d
+  Resale Fee Rate:        %s
+  Allowed Escrow Denoms:  %s
+  Min Escrow Amount:      %s
+  Max Escrow Amount:      %s
+  Dispute Period:         %d
+  Bls Pairing Gas:        %d
+  Bls PubKey Gas:         %d
+`,
+		p.MinNamePrice, p.NameLifetime, p.NameGracePeriod, p.NameRenewalFee,
+		p.AuctionOnly, p.AuctionCommitPeriod, p.AuctionRevealPeriod, p.ResaleFeeRate,
+		strings.Join(p.AllowedEscrowDenoms, ", "), p.MinEscrowAmount, p.MaxEscrowAmount,
+		p.DisputePeriod, p.BlsPairingGas, p.BlsPubKeyGas,
+	)
+}
+
+// ParamSetPairs implements params.ParamSet
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		{Key: KeyMinNamePrice, Value: &p.MinNamePrice},
+		{Key: KeyNameLifetime, Value: &p.NameLifetime},
+		{Key: KeyNameGracePeriod, Value: &p.NameGracePeriod},
+		{Key: KeyNameRenewalFee, Value: &p.NameRenewalFee},
+		{Key: KeyAuctionOnly, Value: &p.AuctionOnly},
+		{Key: KeyAuctionCommitPeriod, Value: &p.AuctionCommitPeriod},
+		{Key: KeyAuctionRevealPeriod, Value: &p.AuctionRevealPeriod},
+		{Key: KeyResaleFeeRate, Value: &p.ResaleFeeRate},
+		{Key: KeyAllowedEscrowDenoms, Value: &p.AllowedEscrowDenoms},
+		{Key: KeyMinEscrowAmount, Value: &p.MinEscrowAmount},
+		{Key: KeyMaxEscrowAmount, Value: &p.MaxEscrowAmount},
+		{Key: KeyDisputePeriod, Value: &p.DisputePeriod},
+		{Key: KeyBlsPairingGas, Value: &p.BlsPairingGas},
+		{Key: KeyBlsPubKeyGas, Value: &p.BlsPubKeyGas},
+	}
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateParamsResaleFeeRate(t *testing.T) {
+	cases := []struct {
+		rate  sdk.Dec
+		valid bool
+	}{
+		{sdk.ZeroDec(), true},
+		{sdk.NewDecWithPrec(5, 2), true},
+		{sdk.OneDec(), true},
+		{sdk.NewDecWithPrec(1000000000000000001, 18), false},
+		{sdk.NewDec(2), false},
+		{sdk.NewDecWithPrec(-1, 2), false},
+		{sdk.Dec{}, false},
+	}
+
+	for _, tc := range cases {
+		params := DefaultParams()
+		params.ResaleFeeRate = tc.rate
+		err := ValidateParams(params)
+		if tc.valid {
+			require.Nil(t, err, tc.rate.String())
+		} else {
+			require.NotNil(t, err, tc.rate.String())
+		}
+	}
+}
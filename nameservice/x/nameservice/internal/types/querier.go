@@ -1,6 +1,9 @@
 package types
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 // QueryResResolve Queries Result Payload for a resolve query
 type QueryResResolve struct {
@@ -12,24 +15,82 @@ func (r QueryResResolve) String() string {
 	return r.Value
 }
 
-// QueryResNames Queries Result Payload for a names query
-type QueryResNames []string
+// Limits on the number of names returned by one names query
+const (
+	DefaultNamesLimit = 100
+	MaxNamesLimit     = 1000
+)
+
+// QueryNamesParams are the page of a names query, passed as the query data
+type QueryNamesParams struct {
+	// First name of the page, taken from the NextKey of the previous page
+	StartKey string `json:"start_key"`
+	// Maximum number of names in the page. Zero uses DefaultNamesLimit
+	Limit int `json:"limit"`
+}
+
+// NewQueryNamesParams creates a new QueryNamesParams
+func NewQueryNamesParams(startKey string, limit int) QueryNamesParams {
+	return QueryNamesParams{
+		StartKey: startKey,
+		Limit:    limit,
+	}
+}
+
+// QueryResNames Queries Result Payload for a names query. Names are sorted
+type QueryResNames struct {
+	Names []string `json:"names"`
+	// Number of owned names across every page
+	Total uint64 `json:"total"`
+	// StartKey of the next page, empty on the last page
+	NextKey string `json:"next_key"`
+}
 
 // implement fmt.Stringer
 func (n QueryResNames) String() string {
-	return strings.Join(n[:], "\n")
+	return strings.TrimSpace(fmt.Sprintf("%s\nTotal: %d\nNextKey: %s", strings.Join(n.Names[:], "\n"), n.Total, n.NextKey))
 }
 
-// Query Result Payload for Orders
-type QueryResOrders []Escrow
+// Limits on the number of orders returned by one orders query
+const (
+	DefaultOrdersLimit = 100
+	MaxOrdersLimit     = 1000
+)
+
+// QueryOrdersParams are the filters and page of an orders query, passed as the query data
+type QueryOrdersParams struct {
+	// One of open, filled or closing. Empty matches every status
+	Status string `json:"status"`
+	// Channel ID of the first order of the page, taken from the NextKey of the previous page
+	StartKey string `json:"start_key"`
+	// Maximum number of orders in the page. Zero uses DefaultOrdersLimit
+	Limit int `json:"limit"`
+}
+
+// NewQueryOrdersParams creates a new QueryOrdersParams
+func NewQueryOrdersParams(status string, startKey string, limit int) QueryOrdersParams {
+	return QueryOrdersParams{
+		Status:   status,
+		StartKey: startKey,
+		Limit:    limit,
+	}
+}
+
+// QueryResOrders Queries Result Payload for an orders query. Orders are sorted by channel ID
+type QueryResOrders struct {
+	Orders []Escrow `json:"orders"`
+	// Number of orders matching the query across every page
+	Total uint64 `json:"total"`
+	// StartKey of the next page, empty on the last page
+	NextKey string `json:"next_key"`
+}
 
 // implement fmt.Stringer
-// TODO might need to make this a JSON object?
 func (n QueryResOrders) String() string {
-	var escrowStrings []string
-	for i := 0; i < len(n); i++ {
-		escrowStrings[i] = n[i].String()
+	escrowStrings := make([]string, len(n.Orders))
+	for i := 0; i < len(n.Orders); i++ {
+		escrowStrings[i] = n.Orders[i].String()
 	}
 
-	return strings.Join(escrowStrings[:], "\n")
+	return strings.TrimSpace(fmt.Sprintf("%s\nTotal: %d\nNextKey: %s", strings.Join(escrowStrings[:], "\n"), n.Total, n.NextKey))
 }
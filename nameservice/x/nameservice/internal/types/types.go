@@ -1,26 +1,28 @@
 package types
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
-// MinNamePrice is Initial Starting Price for a name that was never previously owned
-var MinNamePrice = sdk.Coins{sdk.NewInt64Coin("nametoken", 1)}
-
 // Whois is a struct that contains all the metadata of a name
 type Whois struct {
 	Value string         `json:"value"`
 	Owner sdk.AccAddress `json:"owner"`
 	Price sdk.Coins      `json:"price"`
+	// Height the name expires at. The owner can renew it until the grace period after it ends
+	ExpiryHeight int64 `json:"expiry_height"`
 }
 
-// NewWhois returns a new Whois with the minprice as the price
-func NewWhois() Whois {
+// NewWhois returns a new Whois with minPrice as the price
+func NewWhois(minPrice sdk.Coins) Whois {
 	return Whois{
-		Price: MinNamePrice,
+		Price: minPrice,
 	}
 }
 
@@ -28,24 +30,45 @@ func NewWhois() Whois {
 func (w Whois) String() string {
 	return strings.TrimSpace(fmt.Sprintf(`Owner: %s
 Value: %s
-Price: %s`, w.Owner, w.Value, w.Price))
+Price: %s
+Expiry Height: %d`, w.Owner, w.Value, w.Price, w.ExpiryHeight))
+}
+
+// IsExpired returns whether the name has expired at height
+func (w Whois) IsExpired(height int64) bool {
+	return height >= w.ExpiryHeight
 }
 
-type Bls12381PubKey = [96]byte
-type Bls12381Signature = [48]byte
+// Statuses an Escrow moves through, used to filter orders
+const (
+	StatusOpen    = "open"
+	StatusFilled  = "filled"
+	StatusClosing = "closing"
+)
+
+// IsValidStatus returns whether status is one of the Escrow statuses
+func IsValidStatus(status string) bool {
+	return status == StatusOpen || status == StatusFilled || status == StatusClosing
+}
 
 // Escrow is a struct that contains coins held in escrow that will be released
 // with a time-delay (for disputes) upon the receipt of a valid Bls12-318 signature
 type Escrow struct {
+	// Derived from the Merchant, ChannelToken and the Merchant's channel sequence. Used as the key in the KV
+	ChannelID string `json:"channelID"`
 	// This address is needed to verify the Tx that created this escrow, but may not need to be stored by it (unless required to route funds on claim)
 	Merchant sdk.AccAddress `json:"merchant"`
 	Customer sdk.AccAddress `json:"customer"`
 	// A Bls12-318 PublicKey
-	ChannelState string    `json:"channelState"`
-	ChannelToken string    `json:"channelToken"`
+	ChannelState []byte    `json:"channelState"` // 96 byte Bls12-381 public key
+	ChannelToken []byte    `json:"channelToken"`
 	WalletCommit []byte    `json:"walletState"`
 	Amount       sdk.Coins `json:"amount"`
-	Filled       bool      `json:"filled"`
+	// The merchant's deposit, which the customer matches when filling the order
+	Deposit sdk.Coins `json:"deposit"`
+	Filled  bool      `json:"filled"`
+	// Set once a close has been submitted, nil while the channel is open
+	Close *PendingClose `json:"close"`
 	// Denom string `json:"denom"` // stored in sdk.Coin
 }
 
@@ -60,13 +83,178 @@ func NewEscrow() Escrow {
 // implement fmt.String
 func (e Escrow) String() string {
 	return strings.TrimSpace(fmt.Sprintf(
-		`Merchant: %s
+		`ChannelID: %s
+		Merchant: %s
 		Customer: %s
-		ChannelState: %s
-		ChannelToken: %s
-		WalletCommit: %s
+		ChannelState: %X
+		ChannelToken: %X
+		WalletCommit: %X
 		Amount: %s
-		Filled: %d`,
-		e.Merchant, e.Customer, e.ChannelState, e.ChannelToken, e.WalletCommit, e.Amount, e.Filled,
+		Deposit: %s
+		Filled: %t
+		Closing: %t`,
+		e.ChannelID, e.Merchant, e.Customer, e.ChannelState, e.ChannelToken, e.WalletCommit, e.Amount, e.Deposit, e.Filled, e.IsClosing(),
 	))
 }
+
+// Status returns whether the escrow is waiting to be filled, filled, or closing
+func (e Escrow) Status() string {
+	switch {
+	case e.IsClosing():
+		return StatusClosing
+	case e.Filled:
+		return StatusFilled
+	default:
+		return StatusOpen
+	}
+}
+
+// ExpectedAmount returns the amount the escrow must hold, which is the merchant's deposit and,
+// once filled, the customer's matching deposit
+func (e Escrow) ExpectedAmount() sdk.Coins {
+	if e.Filled {
+		return e.Deposit.Add(e.Deposit)
+	}
+	return e.Deposit
+}
+
+// IsClosing returns whether a close has been submitted and is waiting out the dispute period
+func (e Escrow) IsClosing() bool {
+	return e.Close != nil
+}
+
+// ChannelPubKey returns the merchant's Bls12-318 PublicKey held in ChannelState
+func (e Escrow) ChannelPubKey() (Bls12381PubKey, error) {
+	var pubKey Bls12381PubKey
+	if len(e.ChannelState) != len(pubKey) {
+		return pubKey, fmt.Errorf("channel state must be %d bytes, got %d", len(pubKey), len(e.ChannelState))
+	}
+	copy(pubKey[:], e.ChannelState)
+	return pubKey, nil
+}
+
+// NewChannelID derives a deterministic channel ID from the merchant, the channel token and the merchant's channel sequence
+func NewChannelID(merchant sdk.AccAddress, channelToken []byte, sequence uint64) string {
+	hash := sha256.New()
+	hash.Write(merchant.Bytes())
+	hash.Write(channelToken)
+	hash.Write(sdk.Uint64ToBigEndian(sequence))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// WalletPubKey returns the customer's Bls12-318 PublicKey held in WalletCommit
+func (e Escrow) WalletPubKey() (Bls12381PubKey, error) {
+	var pubKey Bls12381PubKey
+	if len(e.WalletCommit) != len(pubKey) {
+		return pubKey, fmt.Errorf("wallet commit must be %d bytes, got %d", len(pubKey), len(e.WalletCommit))
+	}
+	copy(pubKey[:], e.WalletCommit)
+	return pubKey, nil
+}
+
+// ChannelBalance is the final split of a filled escrow between its merchant and customer.
+// Both parties sign every balance the channel moves to, the merchant with the ChannelState key and the
+// customer with the WalletCommit key, and each closes or disputes with the other party's signature
+type ChannelBalance struct {
+	ChannelID string `json:"channelID"`
+	// Incremented on every payment so that a newer balance can override an older one
+	Nonce           uint64    `json:"nonce"`
+	MerchantBalance sdk.Coins `json:"merchantBalance"`
+	CustomerBalance sdk.Coins `json:"customerBalance"`
+}
+
+// NewChannelBalance returns a new ChannelBalance
+func NewChannelBalance(channelID string, nonce uint64, merchantBalance sdk.Coins, customerBalance sdk.Coins) ChannelBalance {
+	return ChannelBalance{
+		ChannelID:       channelID,
+		Nonce:           nonce,
+		MerchantBalance: merchantBalance,
+		CustomerBalance: customerBalance,
+	}
+}
+
+// GetSignBytes encodes the balance for signing with a Bls12-318 key
+func (b ChannelBalance) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(b))
+}
+
+// Total returns the sum of both balances, which must equal the escrowed amount
+func (b ChannelBalance) Total() sdk.Coins {
+	return b.MerchantBalance.Add(b.CustomerBalance)
+}
+
+// Revocation is signed by the customer with the WalletCommit key to give up a wallet state
+// once the channel has moved on to a newer one. Revealing it punishes a close on that state
+type Revocation struct {
+	ChannelID string `json:"channelID"`
+	Nonce     uint64 `json:"nonce"`
+}
+
+// NewRevocation returns a new Revocation
+func NewRevocation(channelID string, nonce uint64) Revocation {
+	return Revocation{
+		ChannelID: channelID,
+		Nonce:     nonce,
+	}
+}
+
+// GetSignBytes encodes the revocation for signing with a Bls12-318 key
+func (r Revocation) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(r))
+}
+
+// PossessionProof is signed by the merchant with the ChannelState key when creating an order,
+// proving they control the key. Binding it to the chain ID keeps proofs from being replayed on other chains
+type PossessionProof struct {
+	Merchant sdk.AccAddress `json:"merchant"`
+	ChainID  string         `json:"chainID"`
+}
+
+// NewPossessionProof returns a new PossessionProof
+func NewPossessionProof(merchant sdk.AccAddress, chainID string) PossessionProof {
+	return PossessionProof{
+		Merchant: merchant,
+		ChainID:  chainID,
+	}
+}
+
+// GetSignBytes encodes the proof for signing with a Bls12-318 key
+func (p PossessionProof) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(p))
+}
+
+// BatchClaimResult is the outcome of one claim in a MsgBatchClaim, returned in the result data
+type BatchClaimResult struct {
+	ChannelID string            `json:"channelID"`
+	Closing   bool              `json:"closing"`
+	Codespace sdk.CodespaceType `json:"codespace,omitempty"`
+	Code      sdk.CodeType      `json:"code,omitempty"`
+	Error     string            `json:"error,omitempty"`
+}
+
+// SetError records why the claim was rejected
+func (r *BatchClaimResult) SetError(err sdk.Error) {
+	r.Codespace = err.Codespace()
+	r.Code = err.Code()
+	r.Error = err.Error()
+}
+
+// PendingClose is a ChannelBalance submitted to close an escrow, which is paid out once MatureHeight is reached
+type PendingClose struct {
+	Balance      ChannelBalance `json:"balance"`
+	Initiator    sdk.AccAddress `json:"initiator"`
+	Height       int64          `json:"height"`
+	Time         time.Time      `json:"time"`
+	MatureHeight int64          `json:"matureHeight"`
+}
+
+// NewPendingClose returns a PendingClose submitted at height and time that matures after the dispute period
+func NewPendingClose(balance ChannelBalance, initiator sdk.AccAddress, height int64, closeTime time.Time, disputePeriod int64) PendingClose {
+	return PendingClose{
+		Balance:      balance,
+		Initiator:    initiator,
+		Height:       height,
+		Time:         closeTime,
+		MatureHeight: height + disputePeriod,
+	}
+}
@@ -0,0 +1,57 @@
+package simulation
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+
+	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice/internal/types"
+)
+
+// DecodeStore unmarshals the KVPair's Value to the corresponding nameservice type
+func DecodeStore(cdcA, cdcB *codec.Codec, kvA, kvB cmn.KVPair) string {
+	switch {
+	case bytes.Equal(kvA.Key[:1], types.WhoisPrefix):
+		var whoisA, whoisB types.Whois
+		cdcA.MustUnmarshalBinaryBare(kvA.Value, &whoisA)
+		cdcB.MustUnmarshalBinaryBare(kvB.Value, &whoisB)
+		return fmt.Sprintf("%v\n%v", whoisA, whoisB)
+
+	case bytes.Equal(kvA.Key[:1], types.EscrowPrefix):
+		var escrowA, escrowB types.Escrow
+		cdcA.MustUnmarshalBinaryBare(kvA.Value, &escrowA)
+		cdcB.MustUnmarshalBinaryBare(kvB.Value, &escrowB)
+		return fmt.Sprintf("%v\n%v", escrowA, escrowB)
+
+	case bytes.Equal(kvA.Key[:1], types.StoreVersionKey),
+		bytes.Equal(kvA.Key[:1], types.ChannelSequencePrefix),
+		bytes.Equal(kvA.Key[:1], types.OrderCountPrefix),
+		bytes.Equal(kvA.Key[:1], types.NameCountKey):
+		return fmt.Sprintf("%d\n%d", binary.BigEndian.Uint64(kvA.Value), binary.BigEndian.Uint64(kvB.Value))
+
+	case bytes.Equal(kvA.Key[:1], types.AuctionPrefix):
+		var auctionA, auctionB types.Auction
+		cdcA.MustUnmarshalBinaryBare(kvA.Value, &auctionA)
+		cdcB.MustUnmarshalBinaryBare(kvB.Value, &auctionB)
+		return fmt.Sprintf("%v\n%v", auctionA, auctionB)
+
+	// the expiry and auction queues hold names
+	case bytes.Equal(kvA.Key[:1], types.ExpiryQueuePrefix),
+		bytes.Equal(kvA.Key[:1], types.AuctionQueuePrefix):
+		return fmt.Sprintf("%s\n%s", kvA.Value, kvB.Value)
+
+	// the closing queue and the escrow indexes hold channel IDs
+	case bytes.Equal(kvA.Key[:1], types.ClosingQueuePrefix),
+		bytes.Equal(kvA.Key[:1], types.MerchantIndexPrefix),
+		bytes.Equal(kvA.Key[:1], types.CustomerIndexPrefix),
+		bytes.Equal(kvA.Key[:1], types.StatusIndexPrefix):
+		return fmt.Sprintf("%s\n%s", kvA.Value, kvB.Value)
+
+	default:
+		panic(fmt.Sprintf("invalid nameservice key prefix %X", kvA.Key[:1]))
+	}
+}
@@ -0,0 +1,157 @@
+package simulation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+
+	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice"
+)
+
+// Simulation parameter keys of the nameservice genesis
+const (
+	MinNamePrice        = "min_name_price"
+	NameLifetime        = "name_lifetime"
+	NameGracePeriod     = "name_grace_period"
+	NameRenewalFee      = "name_renewal_fee"
+	AuctionOnly         = "auction_only"
+	AuctionCommitPeriod = "auction_commit_period"
+	AuctionRevealPeriod = "auction_reveal_period"
+	ResaleFeeRate       = "resale_fee_rate"
+	AllowedEscrowDenoms = "allowed_escrow_denoms"
+	MinEscrowAmount     = "min_escrow_amount"
+	MaxEscrowAmount     = "max_escrow_amount"
+	DisputePeriod       = "dispute_period"
+	BlsPairingGas       = "bls_pairing_gas"
+	BlsPubKeyGas        = "bls_pubkey_gas"
+	NumWhoisRecords     = "num_whois_records"
+)
+
+// GenNameserviceGenesisState generates a random GenesisState for nameservice. Names are priced and
+// escrowed in the bond denomination, the only one simulation accounts hold
+func GenNameserviceGenesisState(cdc *codec.Codec, r *rand.Rand, accs []simulation.Account, ap simulation.AppParams, genesisState map[string]json.RawMessage) {
+	var minNamePrice sdk.Coins
+	ap.GetOrGenerate(cdc, MinNamePrice, &minNamePrice, r,
+		func(r *rand.Rand) {
+			minNamePrice = sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, int64(simulation.RandIntBetween(r, 1, 1000))))
+		})
+
+	var nameLifetime int64
+	ap.GetOrGenerate(cdc, NameLifetime, &nameLifetime, r,
+		func(r *rand.Rand) {
+			nameLifetime = int64(simulation.RandIntBetween(r, 10, 1000))
+		})
+
+	var nameGracePeriod int64
+	ap.GetOrGenerate(cdc, NameGracePeriod, &nameGracePeriod, r,
+		func(r *rand.Rand) {
+			nameGracePeriod = int64(r.Intn(100))
+		})
+
+	var nameRenewalFee sdk.Coins
+	ap.GetOrGenerate(cdc, NameRenewalFee, &nameRenewalFee, r,
+		func(r *rand.Rand) {
+			nameRenewalFee = sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, r.Int63n(100)))
+		})
+
+	var auctionOnly bool
+	ap.GetOrGenerate(cdc, AuctionOnly, &auctionOnly, r,
+		func(r *rand.Rand) {
+			auctionOnly = r.Intn(4) == 0
+		})
+
+	var auctionCommitPeriod int64
+	ap.GetOrGenerate(cdc, AuctionCommitPeriod, &auctionCommitPeriod, r,
+		func(r *rand.Rand) {
+			auctionCommitPeriod = int64(simulation.RandIntBetween(r, 1, 20))
+		})
+
+	var auctionRevealPeriod int64
+	ap.GetOrGenerate(cdc, AuctionRevealPeriod, &auctionRevealPeriod, r,
+		func(r *rand.Rand) {
+			auctionRevealPeriod = int64(simulation.RandIntBetween(r, 1, 20))
+		})
+
+	var resaleFeeRate sdk.Dec
+	ap.GetOrGenerate(cdc, ResaleFeeRate, &resaleFeeRate, r,
+		func(r *rand.Rand) {
+			resaleFeeRate = sdk.NewDecWithPrec(int64(r.Intn(21)), 2)
+		})
+
+	var allowedEscrowDenoms []string
+	ap.GetOrGenerate(cdc, AllowedEscrowDenoms, &allowedEscrowDenoms, r,
+		func(r *rand.Rand) {
+			allowedEscrowDenoms = []string{}
+			if r.Intn(2) == 0 {
+				allowedEscrowDenoms = []string{sdk.DefaultBondDenom}
+			}
+		})
+
+	var minEscrowAmount sdk.Int
+	ap.GetOrGenerate(cdc, MinEscrowAmount, &minEscrowAmount, r,
+		func(r *rand.Rand) {
+			minEscrowAmount = sdk.NewInt(int64(simulation.RandIntBetween(r, 1, 1000)))
+		})
+
+	var maxEscrowAmount sdk.Int
+	ap.GetOrGenerate(cdc, MaxEscrowAmount, &maxEscrowAmount, r,
+		func(r *rand.Rand) {
+			maxEscrowAmount = sdk.ZeroInt()
+			if r.Intn(2) == 0 {
+				maxEscrowAmount = minEscrowAmount.Add(sdk.NewInt(r.Int63n(1e9)))
+			}
+		})
+
+	var disputePeriod int64
+	ap.GetOrGenerate(cdc, DisputePeriod, &disputePeriod, r,
+		func(r *rand.Rand) {
+			disputePeriod = int64(simulation.RandIntBetween(r, 1, 200))
+		})
+
+	var blsPairingGas uint64
+	ap.GetOrGenerate(cdc, BlsPairingGas, &blsPairingGas, r,
+		func(r *rand.Rand) {
+			blsPairingGas = uint64(simulation.RandIntBetween(r, 1000, 40000))
+		})
+
+	var blsPubKeyGas uint64
+	ap.GetOrGenerate(cdc, BlsPubKeyGas, &blsPubKeyGas, r,
+		func(r *rand.Rand) {
+			blsPubKeyGas = uint64(simulation.RandIntBetween(r, 0, 4000))
+		})
+
+	var numWhoisRecords int
+	ap.GetOrGenerate(cdc, NumWhoisRecords, &numWhoisRecords, r,
+		func(r *rand.Rand) {
+			numWhoisRecords = r.Intn(len(accs) + 1)
+		})
+
+	params := nameservice.NewParams(minNamePrice, nameLifetime, nameGracePeriod, nameRenewalFee,
+		auctionOnly, auctionCommitPeriod, auctionRevealPeriod, resaleFeeRate, allowedEscrowDenoms, minEscrowAmount, maxEscrowAmount,
+		disputePeriod, blsPairingGas, blsPubKeyGas)
+
+	// Names are distinct because each one ends in its index
+	records := make([]nameservice.WhoisRecord, numWhoisRecords)
+	for i := range records {
+		price := minNamePrice.Add(sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, r.Int63n(1000))))
+		records[i] = nameservice.WhoisRecord{
+			Name: fmt.Sprintf("%s%d", simulation.RandStringOfLength(r, simulation.RandIntBetween(r, 1, 10)), i),
+			Whois: nameservice.Whois{
+				Value: simulation.RandStringOfLength(r, simulation.RandIntBetween(r, 1, 20)),
+				Owner: simulation.RandomAcc(r, accs).Address,
+				Price: price,
+				// Genesis names are part way through their lifetime
+				ExpiryHeight: int64(simulation.RandIntBetween(r, 1, int(nameLifetime))),
+			},
+		}
+	}
+
+	nameserviceGenesis := nameservice.NewGenesisState(records, []nameservice.Escrow{}, []nameservice.ChannelSequence{}, []nameservice.Auction{}, params)
+
+	fmt.Printf("Selected randomly generated nameservice parameters:\n%s\n", codec.MustMarshalJSONIndent(cdc, nameserviceGenesis.Params))
+	genesisState[nameservice.ModuleName] = cdc.MustMarshalJSON(nameserviceGenesis)
+}
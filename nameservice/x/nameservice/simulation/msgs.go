@@ -0,0 +1,496 @@
+package simulation
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/rand"
+
+	"github.com/phoreproject/bls/g2pubs"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+
+	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice"
+	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice/internal/types"
+)
+
+// SimulateMsgBuyName generates a MsgBuyName for a new or an owned name with a random bid the buyer can afford
+func SimulateMsgBuyName(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		name, ok := randomName(r, ctx, k)
+		if !ok || r.Intn(2) == 0 {
+			name = simulation.RandStringOfLength(r, simulation.RandIntBetween(r, 1, 10))
+		}
+
+		buyer := simulation.RandomAcc(r, accs)
+		price := k.GetPrice(ctx, name)
+		if price.Len() != 1 {
+			return simulation.NoOpMsg(nameservice.ModuleName), nil, nil
+		}
+		denom := price[0].Denom
+
+		spendable := k.CoinKeeper.GetCoins(ctx, buyer.Address).AmountOf(denom)
+		if spendable.LT(price[0].Amount) {
+			return simulation.NoOpMsg(nameservice.ModuleName), nil, nil
+		}
+		bid := sdk.NewCoins(sdk.NewCoin(denom, price[0].Amount.Add(simulation.RandomAmount(r, spendable.Sub(price[0].Amount)))))
+
+		msg := nameservice.NewMsgBuyName(name, bid, buyer.Address)
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// SimulateMsgSetName generates a MsgSetName from the owner of a random name
+func SimulateMsgSetName(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		name, ok := randomName(r, ctx, k)
+		if !ok {
+			return simulation.NoOpMsg(nameservice.ModuleName), nil, nil
+		}
+
+		value := simulation.RandStringOfLength(r, simulation.RandIntBetween(r, 1, 20))
+		msg := nameservice.NewMsgSetName(name, value, k.GetOwner(ctx, name))
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// SimulateMsgDeleteName generates a MsgDeleteName from the owner of a random name
+func SimulateMsgDeleteName(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		name, ok := randomName(r, ctx, k)
+		if !ok {
+			return simulation.NoOpMsg(nameservice.ModuleName), nil, nil
+		}
+
+		msg := nameservice.NewMsgDeleteName(name, k.GetOwner(ctx, name))
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// SimulateMsgRenewName generates a MsgRenewName from the owner of a random name
+func SimulateMsgRenewName(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		name, ok := randomName(r, ctx, k)
+		if !ok {
+			return simulation.NoOpMsg(nameservice.ModuleName), nil, nil
+		}
+
+		msg := nameservice.NewMsgRenewName(name, k.GetOwner(ctx, name))
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// SimulateMsgCommitBid generates a MsgCommitBid on a name being auctioned, an owned name or a new name,
+// with a random deposit the bidder can afford. The bid is a share of the deposit given by the salt,
+// which is derived from the name and bidder so that it can be recomputed to reveal it. Most bids are
+// revealed by a future operation during the reveal phase, the rest are left to be slashed
+func SimulateMsgCommitBid(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		var name string
+		auction, ok := randomAuction(r, ctx, k, func(auction nameservice.Auction) bool {
+			return auction.IsCommitPhase(ctx.BlockHeight())
+		})
+		if ok && r.Intn(2) == 0 {
+			name = auction.Name
+		} else {
+			name, ok = randomName(r, ctx, k)
+			if !ok || r.Intn(2) == 0 {
+				name = simulation.RandStringOfLength(r, simulation.RandIntBetween(r, 1, 10))
+			}
+		}
+
+		bidder := simulation.RandomAcc(r, accs)
+		price := k.GetPrice(ctx, name)
+		if price.Len() != 1 {
+			return simulation.NoOpMsg(nameservice.ModuleName), nil, nil
+		}
+		denom := price[0].Denom
+
+		spendable := k.CoinKeeper.GetCoins(ctx, bidder.Address).AmountOf(denom)
+		if spendable.LT(price[0].Amount) {
+			return simulation.NoOpMsg(nameservice.ModuleName), nil, nil
+		}
+		deposit := sdk.NewCoins(sdk.NewCoin(denom, price[0].Amount.Add(simulation.RandomAmount(r, spendable.Sub(price[0].Amount)))))
+
+		salt := bidSalt(name, bidder.Address)
+		bidHash := nameservice.NewBidHash(name, bidder.Address, bidAmount(deposit, salt), salt)
+		msg := nameservice.NewMsgCommitBid(name, bidder.Address, bidHash, deposit)
+		opMsg, _, err = deliver(ctx, handler, msg)
+		if err != nil || !opMsg.OK || r.Intn(5) == 0 {
+			return opMsg, nil, err
+		}
+
+		auction, _ = k.GetAuction(ctx, name)
+		revealHeight := auction.CommitEndHeight + 1 + r.Int63n(auction.RevealEndHeight-auction.CommitEndHeight)
+		fOps = []simulation.FutureOperation{
+			{BlockHeight: int(revealHeight), Op: simulateMsgRevealBidOf(k, name, bidder.Address)},
+		}
+		return opMsg, fOps, nil
+	}
+}
+
+// SimulateMsgRevealBid generates a MsgRevealBid for a random unrevealed bid on a name in its reveal phase
+func SimulateMsgRevealBid(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		auction, ok := randomAuction(r, ctx, k, func(auction nameservice.Auction) bool {
+			return auction.IsRevealPhase(ctx.BlockHeight())
+		})
+		if !ok {
+			return simulation.NoOpMsg(nameservice.ModuleName), nil, nil
+		}
+
+		var unrevealed []nameservice.SealedBid
+		for _, bid := range auction.Bids {
+			if !bid.Revealed {
+				unrevealed = append(unrevealed, bid)
+			}
+		}
+		if len(unrevealed) == 0 {
+			return simulation.NoOpMsg(nameservice.ModuleName), nil, nil
+		}
+		bid := unrevealed[r.Intn(len(unrevealed))]
+
+		salt := bidSalt(auction.Name, bid.Bidder)
+		msg := nameservice.NewMsgRevealBid(auction.Name, bid.Bidder, bidAmount(bid.Deposit, salt), salt)
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// simulateMsgRevealBidOf generates a MsgRevealBid for the bid a bidder committed on a name
+func simulateMsgRevealBidOf(k nameservice.Keeper, name string, bidder sdk.AccAddress) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		auction, ok := k.GetAuction(ctx, name)
+		if !ok {
+			return simulation.NoOpMsg(nameservice.ModuleName), nil, nil
+		}
+		i := auction.BidIndex(bidder)
+		if i < 0 || auction.Bids[i].Revealed {
+			return simulation.NoOpMsg(nameservice.ModuleName), nil, nil
+		}
+
+		salt := bidSalt(name, bidder)
+		msg := nameservice.NewMsgRevealBid(name, bidder, bidAmount(auction.Bids[i].Deposit, salt), salt)
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// SimulateMsgCreateOrder generates a MsgCreateOrder escrowing a random amount within the module params.
+// The ChannelState key is derived from the random ChannelToken so later operations can sign with it
+func SimulateMsgCreateOrder(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		params := k.GetParams(ctx)
+		denom := sdk.DefaultBondDenom
+		if len(params.AllowedEscrowDenoms) != 0 {
+			denom = params.AllowedEscrowDenoms[r.Intn(len(params.AllowedEscrowDenoms))]
+		}
+
+		merchant := simulation.RandomAcc(r, accs)
+		max := k.CoinKeeper.GetCoins(ctx, merchant.Address).AmountOf(denom)
+		if params.MaxEscrowAmount.IsPositive() && params.MaxEscrowAmount.LT(max) {
+			max = params.MaxEscrowAmount
+		}
+		if max.LT(params.MinEscrowAmount) || !max.IsPositive() {
+			return simulation.NoOpMsg(nameservice.ModuleName), nil, nil
+		}
+		amount := params.MinEscrowAmount.Add(simulation.RandomAmount(r, max.Sub(params.MinEscrowAmount)))
+		if !amount.IsPositive() {
+			return simulation.NoOpMsg(nameservice.ModuleName), nil, nil
+		}
+
+		channelToken := make([]byte, 32)
+		r.Read(channelToken)
+		secretKey := channelSecretKey(channelToken)
+		channelState := g2pubs.PrivToPub(secretKey).Serialize()
+		proof := g2pubs.Sign(types.NewPossessionProof(merchant.Address, ctx.ChainID()).GetSignBytes(), secretKey).Serialize()
+
+		msg := nameservice.NewMsgCreateOrder(merchant.Address, channelState[:], channelToken, proof[:],
+			sdk.NewCoins(sdk.NewCoin(denom, amount)))
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// SimulateMsgFillOrder generates a MsgFillOrder for a random open order from a random customer who can afford it
+func SimulateMsgFillOrder(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		escrow, ok := randomEscrow(r, ctx, k, types.StatusOpen)
+		if !ok {
+			return simulation.NoOpMsg(nameservice.ModuleName), nil, nil
+		}
+
+		customer := simulation.RandomAcc(r, accs)
+		if !escrow.Amount.IsAllLTE(k.CoinKeeper.GetCoins(ctx, customer.Address)) {
+			return simulation.NoOpMsg(nameservice.ModuleName), nil, nil
+		}
+
+		walletCommit := g2pubs.PrivToPub(walletSecretKey(escrow.ChannelID)).Serialize()
+		msg := nameservice.NewMsgFillOrder(escrow.ChannelID, customer.Address, walletCommit[:], escrow.Amount)
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// SimulateMsgClaimOrder generates a MsgClaimOrder from the merchant of a random filled order with a random balance
+// signed by the customer
+func SimulateMsgClaimOrder(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		escrow, ok := randomEscrow(r, ctx, k, types.StatusFilled)
+		if !ok {
+			return simulation.NoOpMsg(nameservice.ModuleName), nil, nil
+		}
+
+		balance := randomBalance(r, escrow, uint64(r.Intn(100)))
+		signature := signBalance(balance, walletSecretKey(escrow.ChannelID))
+		msg := nameservice.NewMsgClaimOrder(escrow.Merchant, escrow.ChannelID, balance.Nonce,
+			balance.MerchantBalance, balance.CustomerBalance, signature)
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// SimulateMsgBatchClaim generates a MsgBatchClaim from the merchant of a random filled order, claiming it and
+// some of the merchant's other filled orders with random balances under one aggregate customer signature
+func SimulateMsgBatchClaim(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		escrow, ok := randomEscrow(r, ctx, k, types.StatusFilled)
+		if !ok {
+			return simulation.NoOpMsg(nameservice.ModuleName), nil, nil
+		}
+
+		// Orders that are already closing are sometimes included, and are rejected on their own
+		escrows := []nameservice.Escrow{escrow}
+		iterator := k.GetMerchantEscrowsIterator(ctx, escrow.Merchant)
+		for ; iterator.Valid() && len(escrows) < 10; iterator.Next() {
+			other := k.GetEscrow(ctx, string(iterator.Value()))
+			if other.Filled && other.ChannelID != escrow.ChannelID && r.Intn(2) == 0 {
+				escrows = append(escrows, other)
+			}
+		}
+		iterator.Close()
+
+		claims := make([]nameservice.BatchClaim, len(escrows))
+		signatures := make([]*g2pubs.Signature, len(escrows))
+		for i, escrow := range escrows {
+			balance := randomBalance(r, escrow, uint64(r.Intn(100)))
+			claims[i] = nameservice.NewBatchClaim(escrow.ChannelID, balance.Nonce, balance.MerchantBalance, balance.CustomerBalance)
+			signatures[i] = g2pubs.Sign(balance.GetSignBytes(), walletSecretKey(escrow.ChannelID))
+		}
+
+		signature := g2pubs.AggregateSignatures(signatures).Serialize()
+		msg := nameservice.NewMsgBatchClaim(escrow.Merchant, claims, signature[:])
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// SimulateMsgCustomerClose generates a MsgCustomerClose from the customer of a random filled order with a random balance
+// signed by the merchant
+func SimulateMsgCustomerClose(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		escrow, ok := randomEscrow(r, ctx, k, types.StatusFilled)
+		if !ok {
+			return simulation.NoOpMsg(nameservice.ModuleName), nil, nil
+		}
+
+		balance := randomBalance(r, escrow, uint64(r.Intn(100)))
+		signature := signBalance(balance, channelSecretKey(escrow.ChannelToken))
+		msg := nameservice.NewMsgCustomerClose(escrow.Customer, escrow.ChannelID, balance.Nonce,
+			balance.MerchantBalance, balance.CustomerBalance, signature)
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// SimulateMsgDisputeClose generates a MsgDisputeClose from the customer of a random closing order
+// with a newer balance signed by the merchant
+func SimulateMsgDisputeClose(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		escrow, ok := randomEscrow(r, ctx, k, types.StatusClosing)
+		if !ok || !escrow.Close.Initiator.Equals(escrow.Merchant) {
+			return simulation.NoOpMsg(nameservice.ModuleName), nil, nil
+		}
+
+		balance := randomBalance(r, escrow, escrow.Close.Balance.Nonce+uint64(simulation.RandIntBetween(r, 1, 10)))
+		signature := signBalance(balance, channelSecretKey(escrow.ChannelToken))
+		msg := nameservice.NewMsgDisputeClose(escrow.Customer, escrow.ChannelID, balance.Nonce,
+			balance.MerchantBalance, balance.CustomerBalance, signature)
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// SimulateMsgRevokeClose generates a MsgRevokeClose from the merchant of a random order the customer is closing
+func SimulateMsgRevokeClose(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		escrow, ok := randomEscrow(r, ctx, k, types.StatusClosing)
+		if !ok || !escrow.Close.Initiator.Equals(escrow.Customer) {
+			return simulation.NoOpMsg(nameservice.ModuleName), nil, nil
+		}
+
+		revocation := nameservice.NewRevocation(escrow.ChannelID, escrow.Close.Balance.Nonce)
+		token := g2pubs.Sign(revocation.GetSignBytes(), walletSecretKey(escrow.ChannelID)).Serialize()
+		msg := nameservice.NewMsgRevokeClose(escrow.Merchant, escrow.ChannelID, token[:])
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// SimulateMsgMutualClose generates a MsgMutualClose for a random filled or closing order with a random balance
+func SimulateMsgMutualClose(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		status := types.StatusFilled
+		if r.Intn(2) == 0 {
+			status = types.StatusClosing
+		}
+		escrow, ok := randomEscrow(r, ctx, k, status)
+		if !ok {
+			return simulation.NoOpMsg(nameservice.ModuleName), nil, nil
+		}
+
+		balance := randomBalance(r, escrow, 0)
+		msg := nameservice.NewMsgMutualClose(escrow.Merchant, escrow.Customer, escrow.ChannelID,
+			balance.MerchantBalance, balance.CustomerBalance)
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// deliver runs msg through the handler, keeping its state changes only if it succeeds
+func deliver(ctx sdk.Context, handler sdk.Handler, msg sdk.Msg) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+	if msg.ValidateBasic() != nil {
+		return simulation.NoOpMsg(nameservice.ModuleName), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
+	}
+
+	ctx, write := ctx.CacheContext()
+	ok := handler(ctx, msg).IsOK()
+	if ok {
+		write()
+	}
+
+	return simulation.NewOperationMsg(msg, ok, ""), nil, nil
+}
+
+// randomName returns a random owned name
+func randomName(r *rand.Rand, ctx sdk.Context, k nameservice.Keeper) (string, bool) {
+	var names []string
+	iterator := k.GetNamesIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		names = append(names, types.NameFromWhoisKey(iterator.Key()))
+	}
+
+	if len(names) == 0 {
+		return "", false
+	}
+	return names[r.Intn(len(names))], true
+}
+
+// randomAuction returns a random open auction that passes filter
+func randomAuction(r *rand.Rand, ctx sdk.Context, k nameservice.Keeper, filter func(nameservice.Auction) bool) (nameservice.Auction, bool) {
+	var auctions []nameservice.Auction
+	k.IterateAuctions(ctx, func(auction nameservice.Auction) bool {
+		if filter(auction) {
+			auctions = append(auctions, auction)
+		}
+		return false
+	})
+
+	if len(auctions) == 0 {
+		return nameservice.Auction{}, false
+	}
+	return auctions[r.Intn(len(auctions))], true
+}
+
+// randomEscrow returns a random escrow with status
+func randomEscrow(r *rand.Rand, ctx sdk.Context, k nameservice.Keeper, status string) (nameservice.Escrow, bool) {
+	var channelIDs []string
+	iterator := k.GetStatusEscrowsIterator(ctx, status)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		channelIDs = append(channelIDs, string(iterator.Value()))
+	}
+
+	if len(channelIDs) == 0 {
+		return nameservice.Escrow{}, false
+	}
+	return k.GetEscrow(ctx, channelIDs[r.Intn(len(channelIDs))]), true
+}
+
+// randomBalance splits the escrowed amount of a filled order randomly between its merchant and customer
+func randomBalance(r *rand.Rand, escrow nameservice.Escrow, nonce uint64) nameservice.ChannelBalance {
+	coin := escrow.Amount[0]
+	merchantAmount := simulation.RandomAmount(r, coin.Amount)
+	return nameservice.NewChannelBalance(escrow.ChannelID, nonce,
+		sdk.NewCoins(sdk.NewCoin(coin.Denom, merchantAmount)),
+		sdk.NewCoins(sdk.NewCoin(coin.Denom, coin.Amount.Sub(merchantAmount))))
+}
+
+// signBalance signs a channel balance with a Bls12-381 secret key
+func signBalance(balance nameservice.ChannelBalance, secretKey *g2pubs.SecretKey) []byte {
+	signature := g2pubs.Sign(balance.GetSignBytes(), secretKey).Serialize()
+	return signature[:]
+}
+
+// channelSecretKey derives the merchant's ChannelState secret key from the order's channel token
+func channelSecretKey(channelToken []byte) *g2pubs.SecretKey {
+	return g2pubs.DeriveSecretKey(sha256.Sum256(channelToken))
+}
+
+// walletSecretKey derives the customer's WalletCommit secret key from the channel ID of the order they fill
+func walletSecretKey(channelID string) *g2pubs.SecretKey {
+	return g2pubs.DeriveSecretKey(sha256.Sum256([]byte(channelID)))
+}
+
+// bidSalt derives the salt of a bidder's sealed bid on a name
+func bidSalt(name string, bidder sdk.AccAddress) []byte {
+	salt := sha256.Sum256(append([]byte(name), bidder.Bytes()...))
+	return salt[:]
+}
+
+// bidAmount returns the bid sealed with salt, between half and all of the single denomination deposit
+func bidAmount(deposit sdk.Coins, salt []byte) sdk.Coins {
+	coin := deposit[0]
+	amount := coin.Amount.MulRaw(50 + int64(salt[0])%51).QuoRaw(100)
+	if !amount.IsPositive() {
+		return deposit
+	}
+	return sdk.NewCoins(sdk.NewCoin(coin.Denom, amount))
+}
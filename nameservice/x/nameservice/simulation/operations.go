@@ -0,0 +1,57 @@
+package simulation
+
+import (
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+
+	"github.com/cosmos/sdk-tutorials/nameservice/x/nameservice"
+)
+
+// Simulation operation weights of the nameservice messages
+const (
+	OpWeightMsgBuyName       = "op_weight_msg_buy_name"
+	OpWeightMsgSetName       = "op_weight_msg_set_name"
+	OpWeightMsgDeleteName    = "op_weight_msg_delete_name"
+	OpWeightMsgRenewName     = "op_weight_msg_renew_name"
+	OpWeightMsgCommitBid     = "op_weight_msg_commit_bid"
+	OpWeightMsgRevealBid     = "op_weight_msg_reveal_bid"
+	OpWeightMsgCreateOrder   = "op_weight_msg_create_order"
+	OpWeightMsgFillOrder     = "op_weight_msg_fill_order"
+	OpWeightMsgClaimOrder    = "op_weight_msg_claim_order"
+	OpWeightMsgBatchClaim    = "op_weight_msg_batch_claim"
+	OpWeightMsgCustomerClose = "op_weight_msg_customer_close"
+	OpWeightMsgDisputeClose  = "op_weight_msg_dispute_close"
+	OpWeightMsgRevokeClose   = "op_weight_msg_revoke_close"
+	OpWeightMsgMutualClose   = "op_weight_msg_mutual_close"
+)
+
+// WeightedOperations returns every nameservice operation, weighted by the app params or by a default weight
+func WeightedOperations(ap simulation.AppParams, cdc *codec.Codec, k nameservice.Keeper) []simulation.WeightedOperation {
+	weight := func(key string, defaultWeight int) int {
+		var v int
+		ap.GetOrGenerate(cdc, key, &v, nil,
+			func(_ *rand.Rand) {
+				v = defaultWeight
+			})
+		return v
+	}
+
+	return []simulation.WeightedOperation{
+		{Weight: weight(OpWeightMsgBuyName, 100), Op: SimulateMsgBuyName(k)},
+		{Weight: weight(OpWeightMsgSetName, 100), Op: SimulateMsgSetName(k)},
+		{Weight: weight(OpWeightMsgDeleteName, 20), Op: SimulateMsgDeleteName(k)},
+		{Weight: weight(OpWeightMsgRenewName, 50), Op: SimulateMsgRenewName(k)},
+		{Weight: weight(OpWeightMsgCommitBid, 50), Op: SimulateMsgCommitBid(k)},
+		{Weight: weight(OpWeightMsgRevealBid, 80), Op: SimulateMsgRevealBid(k)},
+		{Weight: weight(OpWeightMsgCreateOrder, 100), Op: SimulateMsgCreateOrder(k)},
+		{Weight: weight(OpWeightMsgFillOrder, 80), Op: SimulateMsgFillOrder(k)},
+		{Weight: weight(OpWeightMsgClaimOrder, 40), Op: SimulateMsgClaimOrder(k)},
+		{Weight: weight(OpWeightMsgBatchClaim, 20), Op: SimulateMsgBatchClaim(k)},
+		{Weight: weight(OpWeightMsgCustomerClose, 40), Op: SimulateMsgCustomerClose(k)},
+		{Weight: weight(OpWeightMsgDisputeClose, 20), Op: SimulateMsgDisputeClose(k)},
+		{Weight: weight(OpWeightMsgRevokeClose, 20), Op: SimulateMsgRevokeClose(k)},
+		{Weight: weight(OpWeightMsgMutualClose, 40), Op: SimulateMsgMutualClose(k)},
+	}
+}